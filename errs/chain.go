@@ -1,6 +1,10 @@
 package errs
 
-import chain "github.com/g8rswimmer/error-chain"
+import (
+	"errors"
+
+	chain "github.com/g8rswimmer/error-chain"
+)
 
 // Chain holds multiple errors in chain.
 type Chain []error
@@ -26,3 +30,17 @@ func (c Chain) GetError() error {
 
 	return errorChain
 }
+
+// Errors returns the errors of a chain or the error itself if it is not a chain.
+func Errors(err error) []error {
+	if err == nil {
+		return nil
+	}
+
+	var errorChain *chain.ErrorChain
+	if errors.As(err, &errorChain) {
+		return errorChain.Errors()
+	}
+
+	return []error{err}
+}
@@ -33,4 +33,19 @@ func TestErrorChain(t *testing.T) {
 
 		require.NoError(t, errChain.GetError())
 	})
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+
+		first := errors.New("first")   //nolint:goerr113
+		second := errors.New("second") //nolint:goerr113
+
+		errChain := Chain{}
+		errChain.AddIf(first)
+		errChain.AddIf(second)
+
+		require.Equal(t, []error{first, second}, Errors(errChain.GetError()))
+		require.Equal(t, []error{first}, Errors(first))
+		require.Nil(t, Errors(nil))
+	})
 }
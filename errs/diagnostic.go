@@ -0,0 +1,90 @@
+package errs
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// DiagnosticError is implemented by errors that
+// can point to the erroneous input of a query.
+type DiagnosticError interface {
+	error
+	GetDiagnostic() Diagnostic
+}
+
+// Diagnostic describes where and why a query could not be parsed.
+type Diagnostic struct {
+	// Query is the parsed input.
+	Query string
+	// Actual is the input or token type that was found.
+	Actual string
+	// Expected contains the token types that would have been valid.
+	Expected []string
+	// Position is the byte offset of the erroneous input within the query.
+	Position int
+	// Length is the byte length of the erroneous input.
+	Length int
+}
+
+// NewDiagnostic creates a new diagnostic with given arguments.
+func NewDiagnostic(query string, position, length int, actual string, expected ...string) Diagnostic {
+	return Diagnostic{
+		Query:    query,
+		Actual:   actual,
+		Expected: expected,
+		Position: position,
+		Length:   length,
+	}
+}
+
+// GetDiagnostic returns the diagnostic.
+func (d Diagnostic) GetDiagnostic() Diagnostic {
+	return d
+}
+
+// details returns the parameters of the diagnostic.
+func (d Diagnostic) details() map[string]interface{} {
+	details := map[string]interface{}{
+		"actual":   d.Actual,
+		"position": d.Position,
+	}
+
+	if len(d.Expected) > 0 {
+		details["expected"] = append([]string{}, d.Expected...)
+	}
+
+	return details
+}
+
+// Snippet renders the query with a caret under the erroneous input.
+func (d Diagnostic) Snippet() string {
+	if d.Query == "" {
+		return ""
+	}
+
+	position := d.Position
+	if position < 0 {
+		position = 0
+	} else if position > len(d.Query) {
+		position = len(d.Query)
+	}
+
+	end := position + d.Length
+	if end > len(d.Query) {
+		end = len(d.Query)
+	}
+
+	length := utf8.RuneCountInString(d.Query[position:end])
+	if length < 1 {
+		length = 1
+	}
+
+	return d.Query + "\n" +
+		strings.Repeat(" ", utf8.RuneCountInString(d.Query[:position])) +
+		strings.Repeat("^", length)
+}
+
+// expectedString returns the expected token types as text.
+func (d Diagnostic) expectedString() string {
+	return strings.Join(d.Expected, "\", \"")
+}
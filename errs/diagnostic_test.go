@@ -0,0 +1,33 @@
+package errs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnosticSnippet(t *testing.T) {
+	t.Parallel()
+
+	t.Run("WithToken", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, "a==1;bé=in=3\n           ^",
+			NewDiagnostic("a==1;bé=in=3", 12, 1, "NUMERIC_LITERAL", "(").Snippet())
+		require.Equal(t, "name=asc+age\n        ^^^^",
+			NewDiagnostic("name=asc+age", 8, 4, "FIELD_NAME", ",").Snippet())
+	})
+
+	t.Run("WithEndOfInput", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, "(a==1\n     ^",
+			NewDiagnostic("(a==1", 5, 0, "", ")").Snippet())
+	})
+
+	t.Run("WithoutQuery", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, "", NewDiagnostic("", 3, 1, "x").Snippet())
+	})
+}
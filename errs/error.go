@@ -0,0 +1,79 @@
+package errs
+
+import "errors"
+
+// Error is implemented by all error types of this module.
+type Error interface {
+	error
+	// Code returns a stable, machine-readable identifier of the error.
+	Code() string
+	// Path returns the offending field or path, empty if unknown.
+	Path() string
+	// Details returns the parameters of the error.
+	Details() map[string]interface{}
+}
+
+// Categories of errors that can be matched with `errors.Is`.
+var (
+	// ErrSyntax is the category of malformed input.
+	ErrSyntax = errors.New("syntax error")
+	// ErrPolicy is the category of input disallowed by a policy.
+	ErrPolicy = errors.New("policy error")
+	// ErrType is the category of values with invalid type.
+	ErrType = errors.New("type error")
+	// ErrUnknown is the category of references to unknown fields, paths, rules or macros.
+	ErrUnknown = errors.New("unknown reference error")
+	// ErrConstraint is the category of values violating a constraint.
+	ErrConstraint = errors.New("constraint error")
+	// ErrParameter is the category of missing or invalid parameters.
+	ErrParameter = errors.New("parameter error")
+)
+
+// Codes of the errors of this module.
+const (
+	CodeUnexpectedToken       = "unexpected_token"
+	CodeUnexpectedTokenType   = "unexpected_token_type"
+	CodeUnexpectedInputEnd    = "unexpected_input_end"
+	CodeUnexpectedInput       = "unexpected_input"
+	CodePolicyViolation       = "policy_violation"
+	CodeUnboundParameter      = "unbound_parameter"
+	CodeParameterType         = "parameter_type"
+	CodeUnknownMacro          = "unknown_macro"
+	CodeMacroCycle            = "macro_cycle"
+	CodeMacroDepth            = "macro_depth"
+	CodeValueRejected         = "value_rejected"
+	CodeInvalidOperation      = "invalid_operation"
+	CodeOperationNotAllowed   = "operation_not_allowed"
+	CodeUnknownField          = "unknown_field"
+	CodeUnknownPath           = "unknown_path"
+	CodeUnknownRule           = "unknown_rule"
+	CodeUnknownInheritedTag   = "unknown_inherited_tag"
+	CodeTypeMismatch          = "type_mismatch"
+	CodeInvalidType           = "invalid_type"
+	CodeValueTooSmall         = "value_too_small"
+	CodeValueTooLarge         = "value_too_large"
+	CodeExpressionMismatch    = "expression_mismatch"
+	CodeUnsortableField       = "unsortable_field"
+	CodeSortDirection         = "sort_direction_not_allowed"
+	CodeDuplicateKey          = "duplicate_key"
+	CodeKeyLimit              = "key_limit_exceeded"
+	CodeInvalidTag            = "invalid_tag"
+	CodePipelineRequired      = "pipeline_required"
+	CodeTextSearchRequired    = "text_search_required"
+	CodeUnknownOption         = "unknown_option"
+	CodeInvalidOption         = "invalid_option"
+	CodeUnindexedQuery        = "unindexed_query"
+	CodeMixedProjection       = "mixed_projection"
+	CodePathCollision         = "path_collision"
+	CodeInvalidSlice          = "invalid_slice"
+	CodeConflictingParameters = "conflicting_parameters"
+	CodeInvalidParameter      = "invalid_parameter"
+	CodeUnknownPatternKind    = "unknown_pattern_kind"
+	CodeAlwaysFalse           = "always_false"
+	CodeInvalidCursor         = "invalid_cursor"
+	CodeNilReference          = "nil_reference"
+	CodeInvalidLiteral        = "invalid_literal"
+	CodeNoOperation           = "no_operation"
+	CodeUnknownOperation      = "unknown_operation"
+	CodeInvalidConfiguration  = "invalid_configuration"
+)
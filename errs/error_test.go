@@ -0,0 +1,37 @@
+package errs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		err      Error
+		category error
+		code     string
+		path     string
+	}{
+		{NewErrUnexpectedToken(2, "a"), ErrSyntax, CodeUnexpectedToken, ""},
+		{NewErrUnexpectedTokenType(2, "a", "b"), ErrSyntax, CodeUnexpectedTokenType, ""},
+		{NewErrUnexpectedInputEnd("a"), ErrSyntax, CodeUnexpectedInputEnd, ""},
+		{NewErrUnexpectedInput("a"), ErrSyntax, CodeUnexpectedInput, ""},
+		{NewErrPolicyViolation("a"), ErrPolicy, CodePolicyViolation, "a"},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.code, testCase.err.Code())
+		require.Equal(t, testCase.path, testCase.err.Path())
+		require.NotEmpty(t, testCase.err.Details())
+		require.ErrorIs(t, testCase.err, testCase.category)
+		require.NotErrorIs(t, testCase.err, ErrType)
+	}
+
+	var err Error
+	require.True(t, errors.As(NewErrUnexpectedTokenType(2, "a", "b"), &err))
+	require.Equal(t, []string{"b"}, err.Details()["expected"])
+}
@@ -9,15 +9,44 @@ const errPolicyViolationMessage = "Policy violation, policy disallow \"%s\""
 // PolicyViolationError is an error
 // type for policy violation.
 type PolicyViolationError struct {
-	key string
+	Diagnostic
 }
 
 // Error returns the error message text.
 func (err PolicyViolationError) Error() string {
-	return fmt.Sprintf(errPolicyViolationMessage, err.key)
+	return fmt.Sprintf(errPolicyViolationMessage, err.Actual)
 }
 
 // NewErrUnexpectedInputEnd cerate a new error.
 func NewErrPolicyViolation(key string) PolicyViolationError {
-	return PolicyViolationError{key: key}
+	return PolicyViolationError{
+		Diagnostic: Diagnostic{
+			Actual: key,
+		},
+	}
+}
+
+// NewErrPolicyViolationWithDiagnostic cerate a new error.
+func NewErrPolicyViolationWithDiagnostic(diagnostic Diagnostic) PolicyViolationError {
+	return PolicyViolationError{Diagnostic: diagnostic}
+}
+
+// Code returns the error code.
+func (err PolicyViolationError) Code() string {
+	return CodePolicyViolation
+}
+
+// Path returns the disallowed value.
+func (err PolicyViolationError) Path() string {
+	return err.Actual
+}
+
+// Details returns the parameters of the error.
+func (err PolicyViolationError) Details() map[string]interface{} {
+	return err.details()
+}
+
+// Is reports whether the error belongs to given category.
+func (err PolicyViolationError) Is(target error) bool {
+	return target == ErrPolicy
 }
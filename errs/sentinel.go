@@ -0,0 +1,39 @@
+package errs
+
+// SentinelError is an error without parameters that belongs to a category.
+// It is compared by identity, so packages declare it once, e.g. as `ErrAlwaysFalse`.
+type SentinelError struct {
+	category error
+	code     string
+	message  string
+}
+
+// NewErrSentinel cerate a new error of given category and code.
+func NewErrSentinel(category error, code, message string) *SentinelError {
+	return &SentinelError{category: category, code: code, message: message}
+}
+
+// Error returns the error message text.
+func (err *SentinelError) Error() string {
+	return err.message
+}
+
+// Code returns the error code.
+func (err *SentinelError) Code() string {
+	return err.code
+}
+
+// Path returns an empty path since the error has no parameters.
+func (err *SentinelError) Path() string {
+	return ""
+}
+
+// Details returns no parameters.
+func (err *SentinelError) Details() map[string]interface{} {
+	return map[string]interface{}{}
+}
+
+// Is reports whether the error belongs to given category.
+func (err *SentinelError) Is(target error) bool {
+	return target == err.category
+}
@@ -0,0 +1,23 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrSentinel(t *testing.T) {
+	t.Parallel()
+
+	err := NewErrSentinel(ErrConstraint, CodeAlwaysFalse, "a")
+	require.Equal(t, "a", err.Error())
+	require.Equal(t, CodeAlwaysFalse, err.Code())
+	require.Empty(t, err.Path())
+	require.Empty(t, err.Details())
+	require.True(t, errors.Is(fmt.Errorf("b: %w", err), err))
+	require.True(t, errors.Is(fmt.Errorf("b: %w", err), ErrConstraint))
+	require.False(t, errors.Is(err, ErrPolicy))
+	require.False(t, errors.Is(err, NewErrSentinel(ErrConstraint, CodeAlwaysFalse, "a")))
+}
@@ -0,0 +1,110 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Translator renders the message of an error for a locale.
+type Translator interface {
+	// Translate returns the message of given error for the locale
+	// and false if the locale or code is not supported.
+	Translate(locale string, err Error) (string, bool)
+}
+
+// Localize renders the message of an error for a locale.
+// Errors that are not supported by the translator fall back to the built-in English message.
+// The errors of a chain are localized one by one, a nil error results in an empty message.
+func Localize(translator Translator, locale string, err error) string {
+	if err == nil {
+		return ""
+	}
+
+	if all := Errors(err); len(all) > 1 {
+		messages := make([]string, 0, len(all))
+		for _, each := range all {
+			messages = append(messages, Localize(translator, locale, each))
+		}
+
+		return strings.Join(messages, ": ")
+	}
+
+	var moduleErr Error
+	if translator != nil && errors.As(err, &moduleErr) {
+		if message, ok := translator.Translate(locale, moduleErr); ok {
+			return message
+		}
+	}
+
+	return err.Error()
+}
+
+// NewCatalog creates a new empty message catalog.
+func NewCatalog() *Catalog {
+	return &Catalog{messages: map[string]map[string]string{}}
+}
+
+// Catalog is a translator based on message templates per locale and error code.
+// Templates reference the details of an error with `{name}`, e.g. `Feld "{path}" ist unbekannt`.
+// A locale like `de-CH` falls back to `de` if not registered.
+type Catalog struct {
+	messages map[string]map[string]string
+	mutex    sync.RWMutex
+}
+
+// Register adds the message templates by error code for given locale.
+// Existing templates of the locale are overwritten.
+func (c *Catalog) Register(locale string, templates map[string]string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	locale = strings.ToLower(locale)
+	if _, exists := c.messages[locale]; !exists {
+		c.messages[locale] = map[string]string{}
+	}
+
+	for code, template := range templates {
+		c.messages[locale][code] = template
+	}
+}
+
+// Translate returns the message of given error for the locale.
+func (c *Catalog) Translate(locale string, err Error) (string, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	locale = strings.ToLower(locale)
+
+	for locale != "" {
+		if template, exists := c.messages[locale][err.Code()]; exists {
+			return render(template, err.Details()), true
+		}
+
+		separator := strings.LastIndexAny(locale, "-_")
+		if separator < 0 {
+			break
+		}
+
+		locale = locale[:separator]
+	}
+
+	return "", false
+}
+
+// render replaces the placeholders of a template with given details.
+func render(template string, details map[string]interface{}) string {
+	replacements := make([]string, 0, len(details)*2) //nolint:gomnd
+
+	for name, value := range details {
+		text := fmt.Sprint(value)
+		if list, isList := value.([]string); isList {
+			text = strings.Join(list, ", ")
+		}
+
+		replacements = append(replacements, "{"+name+"}", text)
+	}
+
+	return strings.NewReplacer(replacements...).Replace(template)
+}
@@ -0,0 +1,62 @@
+package errs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalog(t *testing.T) {
+	t.Parallel()
+
+	catalog := NewCatalog()
+	catalog.Register("de", map[string]string{
+		CodePolicyViolation:     "Richtlinie verbietet \"{actual}\"",
+		CodeUnexpectedTokenType: "Unerwartetes Token \"{actual}\" an Position {position}, erwartet: {expected}",
+	})
+	catalog.Register("fr", map[string]string{
+		CodePolicyViolation: "La politique interdit \"{actual}\"",
+	})
+
+	t.Run("Translate_Success", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, "Richtlinie verbietet \"a\"",
+			Localize(catalog, "de", NewErrPolicyViolation("a")))
+		require.Equal(t, "La politique interdit \"a\"",
+			Localize(catalog, "fr", NewErrPolicyViolation("a")))
+		require.Equal(t, "Unerwartetes Token \"b\" an Position 3, erwartet: c, d",
+			Localize(catalog, "de", NewErrUnexpectedTokenTypeWithDiagnostic(NewDiagnostic("", 3, 1, "b", "c", "d"))))
+	})
+
+	t.Run("RegionFallback_Success", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, "Richtlinie verbietet \"a\"",
+			Localize(catalog, "de-CH", NewErrPolicyViolation("a")))
+	})
+
+	t.Run("Chain_Success", func(t *testing.T) {
+		t.Parallel()
+
+		errChain := Chain{}
+		errChain.AddIf(NewErrPolicyViolation("a"))
+		errChain.AddIf(NewErrUnexpectedInput("b"))
+
+		require.Equal(t, "Richtlinie verbietet \"a\": "+NewErrUnexpectedInput("b").Error(),
+			Localize(catalog, "de", errChain.GetError()))
+	})
+
+	t.Run("EnglishFallback_Success", func(t *testing.T) {
+		t.Parallel()
+
+		err := NewErrUnexpectedInput("a")
+		require.Equal(t, err.Error(), Localize(catalog, "de", err))
+		require.Equal(t, NewErrPolicyViolation("a").Error(), Localize(catalog, "it", NewErrPolicyViolation("a")))
+		require.Equal(t, "test", Localize(catalog, "de", errors.New("test"))) //nolint:goerr113
+		require.Equal(t, err.Error(), Localize(nil, "de", err))
+		require.Empty(t, Localize(catalog, "de", nil))
+		require.Empty(t, Localize(nil, "de", nil))
+	})
+}
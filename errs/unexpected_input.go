@@ -21,3 +21,28 @@ func (err UnexpectedInputError) Error() string {
 func NewErrUnexpectedInput(data interface{}) UnexpectedInputError {
 	return UnexpectedInputError{data: data}
 }
+
+// Data returns the unexpected input.
+func (err UnexpectedInputError) Data() interface{} {
+	return err.data
+}
+
+// Code returns the error code.
+func (err UnexpectedInputError) Code() string {
+	return CodeUnexpectedInput
+}
+
+// Path returns an empty string since the input has no path.
+func (err UnexpectedInputError) Path() string {
+	return ""
+}
+
+// Details returns the parameters of the error.
+func (err UnexpectedInputError) Details() map[string]interface{} {
+	return map[string]interface{}{"actual": err.data}
+}
+
+// Is reports whether the error belongs to given category.
+func (err UnexpectedInputError) Is(target error) bool {
+	return target == ErrSyntax
+}
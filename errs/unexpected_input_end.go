@@ -9,15 +9,44 @@ const errUnexpectedInputEndMessage = "Unexpected end of input, expected: \"%s\""
 // UnexpectedInputEndError is an error
 // type for unexpected input end.
 type UnexpectedInputEndError struct {
-	tokenType string
+	Diagnostic
 }
 
 // Error returns the error message text.
 func (err UnexpectedInputEndError) Error() string {
-	return fmt.Sprintf(errUnexpectedInputEndMessage, err.tokenType)
+	return fmt.Sprintf(errUnexpectedInputEndMessage, err.expectedString())
 }
 
 // NewErrUnexpectedInputEnd cerate a new error.
 func NewErrUnexpectedInputEnd(tokenType string) UnexpectedInputEndError {
-	return UnexpectedInputEndError{tokenType: tokenType}
+	return UnexpectedInputEndError{
+		Diagnostic: Diagnostic{
+			Expected: []string{tokenType},
+		},
+	}
+}
+
+// NewErrUnexpectedInputEndWithDiagnostic cerate a new error.
+func NewErrUnexpectedInputEndWithDiagnostic(diagnostic Diagnostic) UnexpectedInputEndError {
+	return UnexpectedInputEndError{Diagnostic: diagnostic}
+}
+
+// Code returns the error code.
+func (err UnexpectedInputEndError) Code() string {
+	return CodeUnexpectedInputEnd
+}
+
+// Path returns an empty string since the error refers to the query.
+func (err UnexpectedInputEndError) Path() string {
+	return ""
+}
+
+// Details returns the parameters of the error.
+func (err UnexpectedInputEndError) Details() map[string]interface{} {
+	return err.details()
+}
+
+// Is reports whether the error belongs to given category.
+func (err UnexpectedInputEndError) Is(target error) bool {
+	return target == ErrSyntax
 }
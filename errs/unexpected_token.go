@@ -7,21 +7,48 @@ const errUnexpectedTokenMessage = "Unexpected token: \"%s\" at position \"%d\""
 // UnexpectedTokenError is an error
 // type for unexpected token.
 type UnexpectedTokenError struct {
-	token    string
-	position int
+	Diagnostic
 }
 
 // Error returns the error message text.
 func (err UnexpectedTokenError) Error() string {
 	return fmt.Sprintf(errUnexpectedTokenMessage,
-		err.token,
-		err.position)
+		err.Actual,
+		err.Position)
 }
 
 // NewErrUnexpectedToken cerate a new error.
 func NewErrUnexpectedToken(position int, token string) UnexpectedTokenError {
 	return UnexpectedTokenError{
-		position: position,
-		token:    token,
+		Diagnostic: Diagnostic{
+			Position: position,
+			Actual:   token,
+			Length:   len(token),
+		},
 	}
 }
+
+// NewErrUnexpectedTokenWithDiagnostic cerate a new error.
+func NewErrUnexpectedTokenWithDiagnostic(diagnostic Diagnostic) UnexpectedTokenError {
+	return UnexpectedTokenError{Diagnostic: diagnostic}
+}
+
+// Code returns the error code.
+func (err UnexpectedTokenError) Code() string {
+	return CodeUnexpectedToken
+}
+
+// Path returns an empty string since the error refers to the query.
+func (err UnexpectedTokenError) Path() string {
+	return ""
+}
+
+// Details returns the parameters of the error.
+func (err UnexpectedTokenError) Details() map[string]interface{} {
+	return err.details()
+}
+
+// Is reports whether the error belongs to given category.
+func (err UnexpectedTokenError) Is(target error) bool {
+	return target == ErrSyntax
+}
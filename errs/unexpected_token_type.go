@@ -9,22 +9,47 @@ const errUnexpectedTokenTypeMessage = "Unexpected token: \"%s\" at position \"%d
 // UnexpectedTokenTypeError.TokenType is an error
 // type for unexpected token type.
 type UnexpectedTokenTypeError struct {
-	actual   string
-	expected string
-	position int
+	Diagnostic
 }
 
 // Error returns the error message text.
 func (err UnexpectedTokenTypeError) Error() string {
 	return fmt.Sprintf(errUnexpectedTokenTypeMessage,
-		err.actual, err.position, err.expected)
+		err.Actual, err.Position, err.expectedString())
 }
 
 // NewErrUnexpectedTokenType cerate a new error.
 func NewErrUnexpectedTokenType(position int, actual, expected string) UnexpectedTokenTypeError {
 	return UnexpectedTokenTypeError{
-		position: position,
-		actual:   actual,
-		expected: expected,
+		Diagnostic: Diagnostic{
+			Position: position,
+			Actual:   actual,
+			Expected: []string{expected},
+		},
 	}
 }
+
+// NewErrUnexpectedTokenTypeWithDiagnostic cerate a new error.
+func NewErrUnexpectedTokenTypeWithDiagnostic(diagnostic Diagnostic) UnexpectedTokenTypeError {
+	return UnexpectedTokenTypeError{Diagnostic: diagnostic}
+}
+
+// Code returns the error code.
+func (err UnexpectedTokenTypeError) Code() string {
+	return CodeUnexpectedTokenType
+}
+
+// Path returns an empty string since the error refers to the query.
+func (err UnexpectedTokenTypeError) Path() string {
+	return ""
+}
+
+// Details returns the parameters of the error.
+func (err UnexpectedTokenTypeError) Details() map[string]interface{} {
+	return err.details()
+}
+
+// Is reports whether the error belongs to given category.
+func (err UnexpectedTokenTypeError) Is(target error) bool {
+	return target == ErrSyntax
+}
@@ -0,0 +1,208 @@
+package binder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+	"github.com/StevenCyb/go-mongo-tools/mongo/collation"
+	"github.com/StevenCyb/go-mongo-tools/mongo/pagination"
+	"github.com/StevenCyb/go-mongo-tools/mongo/projection"
+	"github.com/StevenCyb/go-mongo-tools/mongo/rsql"
+	"github.com/StevenCyb/go-mongo-tools/mongo/sort"
+	"github.com/StevenCyb/go-mongo-tools/tokenizer"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Default names of the query parameters.
+const (
+	FilterParameter     = "query"
+	SortParameter       = "sort"
+	ProjectionParameter = "fields"
+	CollationParameter  = "collation"
+)
+
+// Parameters are the names of the query parameters, empty names are replaced by defaults.
+// The names of the pagination parameters are configured by `pagination.Config`.
+type Parameters struct {
+	Filter     string
+	Sort       string
+	Projection string
+	Collation  string
+}
+
+// Config of a binder and its parsers.
+type Config struct {
+	Parameters Parameters
+	// FilterPolicy is the policy of the rsql parser.
+	FilterPolicy tokenizer.FieldPolicy
+	// SortPolicy is the policy of the sort parser, ignored if `SortReference` is set.
+	SortPolicy tokenizer.FieldPolicy
+	// SortReference creates a smart sort parser for given type.
+	SortReference reflect.Type
+	SortSyntax    sort.Syntax
+	// SortKeyLimit limits the number of sort keys if not zero.
+	SortKeyLimit int
+	// DefaultSort is used if no sort is given.
+	DefaultSort bson.D
+	// ProjectionPolicy is the policy of the projection parser.
+	ProjectionPolicy tokenizer.FieldPolicy
+	Pagination       pagination.Config
+	// CollationPolicy is the policy of the allowed locales of the collation parser.
+	CollationPolicy tokenizer.FieldPolicy
+	// Collation is set on all find options without collation parameter if not nil.
+	Collation *options.Collation
+}
+
+// Query is the result of binding query parameters.
+type Query struct {
+	Filter     bson.D
+	Options    *options.FindOptions
+	Pagination pagination.Pagination
+}
+
+// NewBinder creates a new binder with given configuration.
+func NewBinder(config Config) (*Binder, error) {
+	binder := &Binder{
+		parameters:       withDefaults(config.Parameters),
+		filter:           rsql.NewParser(config.FilterPolicy),
+		sort:             sort.NewParser(config.SortPolicy, config.SortSyntax),
+		projection:       projection.NewParser(config.ProjectionPolicy),
+		collation:        collation.NewParser(config.CollationPolicy),
+		pagination:       pagination.NewParser(config.Pagination),
+		defaultSort:      config.DefaultSort,
+		defaultCollation: config.Collation,
+	}
+
+	if config.SortReference != nil {
+		var err error
+
+		binder.sort, err = sort.NewSmartParser(config.SortReference, config.SortSyntax)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create sort parser: %w", err)
+		}
+	}
+
+	if config.SortKeyLimit > 0 {
+		binder.sort.SetKeyLimit(config.SortKeyLimit)
+	}
+
+	return binder, nil
+}
+
+// Binder binds the query parameters of list endpoints to a filter and find options.
+// A binder is safe for concurrent use.
+type Binder struct {
+	filter           *rsql.Parser
+	sort             *sort.Parser
+	projection       *projection.Parser
+	pagination       *pagination.Parser
+	collation        *collation.Parser
+	defaultCollation *options.Collation
+	defaultSort      bson.D
+	parameters       Parameters
+}
+
+// Filter returns the rsql parser e.g. to register macros or transformers.
+func (b *Binder) Filter() *rsql.Parser {
+	return b.filter
+}
+
+// Bind given query parameters.
+func (b *Binder) Bind(values url.Values) (Query, error) {
+	return b.BindContext(context.Background(), values)
+}
+
+// BindContext binds given query parameters and passes the context to the policies.
+// Sorting by the text search score requires a `$text` query in the filter
+// or the `sort.TextSearch` capability in the context, e.g. if the filter is extended later.
+// All invalid parameters are returned as `errs.Chain` error of `ParameterError`.
+func (b *Binder) BindContext(ctx context.Context, values url.Values) (Query, error) {
+	var (
+		errChain = errs.Chain{}
+		query    = Query{Options: options.Find()}
+		err      error
+	)
+
+	query.Filter, err = b.filter.ParseContext(ctx, values.Get(b.parameters.Filter))
+	errChain.AddIf(wrap(b.parameters.Filter, err))
+
+	sortContext := sort.WithCapabilities(ctx, sort.CapabilitiesFrom(ctx)|sort.CapabilitiesOf(query.Filter))
+	sortExpression, sortProjection, err := b.sort.ParseWithProjectionContext(sortContext, values.Get(b.parameters.Sort))
+	errChain.AddIf(wrap(b.parameters.Sort, err))
+
+	if len(sortExpression) == 0 {
+		sortExpression = b.defaultSort
+		sortProjection = sort.MetaProjection(b.defaultSort)
+	}
+
+	if len(sortExpression) > 0 {
+		query.Options.SetSort(sortExpression)
+	}
+
+	projectionExpression, err := b.projection.ParseContext(ctx, values.Get(b.parameters.Projection))
+	errChain.AddIf(wrap(b.parameters.Projection, err))
+
+	projectionExpression = append(projectionExpression, sortProjection...)
+
+	if len(projectionExpression) > 0 {
+		query.Options.SetProjection(projectionExpression)
+	}
+
+	collationOptions, err := b.collation.ParseContext(ctx, values.Get(b.parameters.Collation))
+	errChain.AddIf(wrap(b.parameters.Collation, err))
+
+	if collationOptions == nil {
+		collationOptions = b.defaultCollation
+	}
+
+	query.Pagination, err = b.pagination.Parse(values)
+	errChain.AddIf(wrapPagination(err))
+
+	if err := errChain.GetError(); err != nil {
+		return Query{}, err
+	}
+
+	query.Options.SetLimit(query.Pagination.Limit).SetSkip(query.Pagination.Offset)
+
+	if collationOptions != nil {
+		query.Options.SetCollation(collationOptions)
+	}
+
+	return query, nil
+}
+
+// wrapPagination wraps an error of the pagination parser with the parameter it reports.
+func wrapPagination(err error) error {
+	var reason errs.Error
+	if errors.As(err, &reason) {
+		return wrap(reason.Path(), err)
+	}
+
+	return err
+}
+
+// withDefaults replaces empty parameter names by defaults.
+func withDefaults(parameters Parameters) Parameters {
+	if parameters.Filter == "" {
+		parameters.Filter = FilterParameter
+	}
+
+	if parameters.Sort == "" {
+		parameters.Sort = SortParameter
+	}
+
+	if parameters.Projection == "" {
+		parameters.Projection = ProjectionParameter
+	}
+
+	if parameters.Collation == "" {
+		parameters.Collation = CollationParameter
+	}
+
+	return parameters
+}
@@ -0,0 +1,196 @@
+//nolint:funlen
+package binder
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+	"github.com/StevenCyb/go-mongo-tools/mongo/pagination"
+	"github.com/StevenCyb/go-mongo-tools/mongo/sort"
+	"github.com/StevenCyb/go-mongo-tools/tokenizer"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type binderDoc struct {
+	Name string `bson:"name" sort:"true"`
+	Age  int    `bson:"age" sort:"desc"`
+}
+
+func bind(t *testing.T, binder *Binder, query string) (Query, error) {
+	t.Helper()
+
+	values, err := url.ParseQuery(query)
+	require.NoError(t, err)
+
+	return binder.Bind(values)
+}
+
+func TestBinder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("WithDefaults_Success", func(t *testing.T) {
+		t.Parallel()
+
+		binder, err := NewBinder(Config{})
+		require.NoError(t, err)
+
+		query, err := bind(t, binder, "")
+		require.NoError(t, err)
+		require.Equal(t, Query{
+			Filter:     bson.D{},
+			Options:    options.Find().SetLimit(pagination.DefaultLimit).SetSkip(0),
+			Pagination: pagination.Pagination{Limit: pagination.DefaultLimit},
+		}, query)
+	})
+
+	t.Run("WithAllParameters_Success", func(t *testing.T) {
+		t.Parallel()
+
+		collation := &options.Collation{Locale: "de"}
+		binder, err := NewBinder(Config{
+			SortSyntax: sort.PrefixSyntax,
+			Pagination: pagination.Config{MaxLimit: 50},
+			Collation:  collation,
+		})
+		require.NoError(t, err)
+
+		query, err := bind(t, binder, `query=age=ge=18&sort=-age,name&fields=name,-_id&page=3&pageSize=10`)
+		require.NoError(t, err)
+		require.Equal(t, bson.D{{Key: "age", Value: bson.D{{Key: "$gte", Value: int64(18)}}}}, query.Filter)
+		require.Equal(t, options.Find().
+			SetSort(bson.D{{Key: "age", Value: -1}, {Key: "name", Value: 1}}).
+			SetProjection(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 0}}).
+			SetLimit(10).
+			SetSkip(20).
+			SetCollation(collation), query.Options)
+		require.Equal(t, pagination.Pagination{Limit: 10, Offset: 20}, query.Pagination)
+	})
+
+	t.Run("WithCustomParameters_Success", func(t *testing.T) {
+		t.Parallel()
+
+		binder, err := NewBinder(Config{
+			Parameters:  Parameters{Filter: "filter", Sort: "order", Projection: "select"},
+			Pagination:  pagination.Config{Parameters: pagination.Parameters{Limit: "top", Offset: "skip"}},
+			DefaultSort: bson.D{{Key: "name", Value: 1}},
+		})
+		require.NoError(t, err)
+
+		query, err := bind(t, binder, `filter=name=="a"&select=name&top=5&skip=10&query=x&limit=x`)
+		require.NoError(t, err)
+		require.Equal(t, bson.D{{Key: "name", Value: "a"}}, query.Filter)
+		require.Equal(t, options.Find().
+			SetSort(bson.D{{Key: "name", Value: 1}}).
+			SetProjection(bson.D{{Key: "name", Value: 1}}).
+			SetLimit(5).
+			SetSkip(10), query.Options)
+	})
+
+	t.Run("WithSortReference_Success", func(t *testing.T) {
+		t.Parallel()
+
+		binder, err := NewBinder(Config{SortReference: reflect.TypeOf(binderDoc{}), SortKeyLimit: 1})
+		require.NoError(t, err)
+
+		_, err = bind(t, binder, "sort=age=desc")
+		require.NoError(t, err)
+
+		_, err = bind(t, binder, "sort=age=asc")
+		require.ErrorAs(t, errs.Errors(err)[0], &sort.DirectionNotAllowedError{})
+
+		_, err = bind(t, binder, "sort=age=desc,name=asc")
+		require.ErrorAs(t, errs.Errors(err)[0], &sort.KeyLimitError{})
+
+		_, err = NewBinder(Config{SortReference: reflect.TypeOf(struct {
+			Name string `bson:"name" sort:"yes"`
+		}{})})
+		require.ErrorAs(t, err, &sort.InvalidSortTagError{})
+	})
+
+	t.Run("WithCollation_Success", func(t *testing.T) {
+		t.Parallel()
+
+		binder, err := NewBinder(Config{
+			CollationPolicy: tokenizer.NewPolicy(tokenizer.WhitelistPolicy, "de", "tr"),
+			Collation:       &options.Collation{Locale: "simple"},
+		})
+		require.NoError(t, err)
+
+		query, err := bind(t, binder, "collation=locale=tr%3Bstrength=2")
+		require.NoError(t, err)
+		require.Equal(t, &options.Collation{Locale: "tr", Strength: 2}, query.Options.Collation)
+
+		query, err = bind(t, binder, "")
+		require.NoError(t, err)
+		require.Equal(t, &options.Collation{Locale: "simple"}, query.Options.Collation)
+
+		_, err = bind(t, binder, "collation=locale=fr")
+
+		var parameterErr ParameterError
+		require.True(t, errors.As(errs.Errors(err)[0], &parameterErr))
+		require.Equal(t, "collation", parameterErr.Parameter())
+		require.Equal(t, errs.CodePolicyViolation, parameterErr.Code())
+	})
+
+	t.Run("WithTextScore_Success", func(t *testing.T) {
+		t.Parallel()
+
+		binder, err := NewBinder(Config{})
+		require.NoError(t, err)
+
+		values, err := url.ParseQuery("sort=score=meta&fields=name")
+		require.NoError(t, err)
+
+		_, err = binder.Bind(values)
+
+		var parameterErr ParameterError
+		require.True(t, errors.As(errs.Errors(err)[0], &parameterErr))
+		require.Equal(t, "sort", parameterErr.Parameter())
+		require.Equal(t, errs.CodeTextSearchRequired, parameterErr.Code())
+
+		query, err := binder.BindContext(sort.WithCapabilities(context.Background(), sort.TextSearch), values)
+		require.NoError(t, err)
+
+		score := bson.E{Key: "score", Value: bson.D{{Key: "$meta", Value: "textScore"}}}
+		require.Equal(t, bson.D{score}, query.Options.Sort)
+		require.Equal(t, bson.D{{Key: "name", Value: 1}, score}, query.Options.Projection)
+	})
+
+	t.Run("WithInvalidParameters_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		binder, err := NewBinder(Config{
+			FilterPolicy:     tokenizer.NewPolicy(tokenizer.WhitelistPolicy, "name"),
+			ProjectionPolicy: tokenizer.NewPolicy(tokenizer.BlacklistPolicy, "password"),
+		})
+		require.NoError(t, err)
+
+		_, err = bind(t, binder, `query=age==1&sort=name=up&fields=password&limit=0&offset=2&page=1`)
+		require.Error(t, err)
+
+		parameterErrors := errs.Errors(err)
+		require.Len(t, parameterErrors, 4)
+
+		parameters := []string{}
+		codes := []string{}
+
+		for _, err := range parameterErrors {
+			var parameterErr ParameterError
+			require.True(t, errors.As(err, &parameterErr))
+
+			parameters = append(parameters, parameterErr.Parameter())
+			codes = append(codes, parameterErr.Code())
+		}
+
+		require.Equal(t, []string{"query", "sort", "fields", "limit"}, parameters)
+		require.Equal(t, []string{
+			errs.CodePolicyViolation, errs.CodeUnexpectedTokenType, errs.CodePolicyViolation, errs.CodeValueTooSmall,
+		}, codes)
+	})
+}
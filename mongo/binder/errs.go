@@ -0,0 +1,73 @@
+package binder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+)
+
+// ParameterError indicate that a query parameter is invalid.
+type ParameterError struct {
+	err       error
+	parameter string
+}
+
+// wrap returns given error as `ParameterError` of given parameter.
+func wrap(parameter string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return ParameterError{parameter: parameter, err: err}
+}
+
+func (p ParameterError) Error() string {
+	return fmt.Sprintf("parameter '%s' invalid: %s", p.parameter, p.err)
+}
+
+// Unwrap returns the reason why the parameter is invalid.
+func (p ParameterError) Unwrap() error {
+	return p.err
+}
+
+// Parameter returns the name of the invalid parameter.
+func (p ParameterError) Parameter() string {
+	return p.parameter
+}
+
+// Code returns the code of the reason or a generic code if unknown.
+func (p ParameterError) Code() string {
+	var reason errs.Error
+	if errors.As(p.err, &reason) {
+		return reason.Code()
+	}
+
+	return errs.CodeInvalidParameter
+}
+
+// Path returns the path reported by the reason or else the name of the parameter.
+func (p ParameterError) Path() string {
+	var reason errs.Error
+	if errors.As(p.err, &reason) && reason.Path() != "" {
+		return reason.Path()
+	}
+
+	return p.parameter
+}
+
+// Details returns the details of the reason extended by the name of the parameter.
+func (p ParameterError) Details() map[string]interface{} {
+	details := map[string]interface{}{}
+
+	var reason errs.Error
+	if errors.As(p.err, &reason) {
+		for key, value := range reason.Details() {
+			details[key] = value
+		}
+	}
+
+	details["parameter"] = p.parameter
+
+	return details
+}
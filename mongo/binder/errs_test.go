@@ -0,0 +1,26 @@
+package binder
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParameterError(t *testing.T) {
+	t.Parallel()
+
+	reason := errs.NewErrPolicyViolation("password")
+	err := ParameterError{parameter: "fields", err: reason}
+	require.Equal(t, `parameter 'fields' invalid: Policy violation, policy disallow "password"`, err.Error())
+	require.Equal(t, "fields", err.Parameter())
+	require.Equal(t, errs.CodePolicyViolation, err.Code())
+	require.Equal(t, "password", err.Path())
+	require.Equal(t, "fields", err.Details()["parameter"])
+	require.True(t, errors.Is(err, errs.ErrPolicy))
+
+	err = ParameterError{parameter: "sort", err: errors.New("failed")} //nolint:goerr113
+	require.Equal(t, errs.CodeInvalidParameter, err.Code())
+	require.Equal(t, "sort", err.Path())
+}
@@ -0,0 +1,107 @@
+package collation
+
+import (
+	"fmt"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+)
+
+var ErrMissingLocale = errs.NewErrSentinel(errs.ErrConstraint, errs.CodeInvalidOption, "collation requires a locale")
+
+// UnknownOptionError indicate that an option is no collation option.
+type UnknownOptionError struct {
+	errs.Diagnostic
+}
+
+func (u UnknownOptionError) Error() string {
+	return fmt.Sprintf("unknown collation option '%s'", u.Actual)
+}
+
+// Code returns the error code.
+func (u UnknownOptionError) Code() string {
+	return errs.CodeUnknownOption
+}
+
+// Path returns the unknown option.
+func (u UnknownOptionError) Path() string {
+	return u.Actual
+}
+
+// Details returns the parameters of the error.
+func (u UnknownOptionError) Details() map[string]interface{} {
+	return map[string]interface{}{"option": u.Actual}
+}
+
+// Is reports whether the error belongs to given category.
+func (u UnknownOptionError) Is(target error) bool {
+	return target == errs.ErrUnknown
+}
+
+// InvalidOptionError indicate that the value of an option is invalid.
+type InvalidOptionError struct {
+	errs.Diagnostic
+	option string
+}
+
+func (i InvalidOptionError) Error() string {
+	message := fmt.Sprintf("value '%s' of collation option '%s' is invalid", i.Actual, i.option)
+	if len(i.Expected) > 0 {
+		message += fmt.Sprintf(", must be one of %v", i.Expected)
+	}
+
+	return message
+}
+
+// Option returns the option of the invalid value.
+func (i InvalidOptionError) Option() string {
+	return i.option
+}
+
+// Code returns the error code.
+func (i InvalidOptionError) Code() string {
+	return errs.CodeInvalidOption
+}
+
+// Path returns the option of the invalid value.
+func (i InvalidOptionError) Path() string {
+	return i.option
+}
+
+// Details returns the parameters of the error.
+func (i InvalidOptionError) Details() map[string]interface{} {
+	return map[string]interface{}{"option": i.option, "value": i.Actual, "expected": i.Expected}
+}
+
+// Is reports whether the error belongs to given category.
+func (i InvalidOptionError) Is(target error) bool {
+	return target == errs.ErrConstraint
+}
+
+// DuplicateOptionError indicate that an option is set more than once.
+type DuplicateOptionError struct {
+	errs.Diagnostic
+}
+
+func (d DuplicateOptionError) Error() string {
+	return fmt.Sprintf("collation option '%s' is set more than once", d.Actual)
+}
+
+// Code returns the error code.
+func (d DuplicateOptionError) Code() string {
+	return errs.CodeDuplicateKey
+}
+
+// Path returns the duplicate option.
+func (d DuplicateOptionError) Path() string {
+	return d.Actual
+}
+
+// Details returns the parameters of the error.
+func (d DuplicateOptionError) Details() map[string]interface{} {
+	return map[string]interface{}{"option": d.Actual}
+}
+
+// Is reports whether the error belongs to given category.
+func (d DuplicateOptionError) Is(target error) bool {
+	return target == errs.ErrConstraint
+}
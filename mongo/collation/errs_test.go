@@ -0,0 +1,52 @@
+package collation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnknownOptionError(t *testing.T) {
+	t.Parallel()
+
+	err := UnknownOptionError{Diagnostic: errs.NewDiagnostic("lang=de", 0, 4, "lang")}
+	require.Equal(t, "unknown collation option 'lang'", err.Error())
+	require.Equal(t, "lang", err.Path())
+	require.Equal(t, errs.CodeUnknownOption, err.Code())
+	require.True(t, errors.Is(err, errs.ErrUnknown))
+}
+
+func TestInvalidOptionError(t *testing.T) {
+	t.Parallel()
+
+	err := InvalidOptionError{
+		Diagnostic: errs.NewDiagnostic("locale=de;caseFirst=yes", 20, 3, "yes", "upper", "lower", "off"),
+		option:     CaseFirstOption,
+	}
+	require.Equal(t, "value 'yes' of collation option 'caseFirst' is invalid, must be one of [upper lower off]", err.Error())
+	require.Equal(t, CaseFirstOption, err.Option())
+	require.Equal(t, errs.CodeInvalidOption, err.Code())
+	require.True(t, errors.Is(err, errs.ErrConstraint))
+
+	var diagnosticErr errs.DiagnosticError
+	require.True(t, errors.As(err, &diagnosticErr))
+	require.Equal(t, 20, diagnosticErr.GetDiagnostic().Position)
+}
+
+func TestDuplicateOptionError(t *testing.T) {
+	t.Parallel()
+
+	err := DuplicateOptionError{Diagnostic: errs.NewDiagnostic("locale=de;locale=tr", 10, 6, "locale")}
+	require.Equal(t, "collation option 'locale' is set more than once", err.Error())
+	require.Equal(t, errs.CodeDuplicateKey, err.Code())
+	require.True(t, errors.Is(err, errs.ErrConstraint))
+}
+
+func TestMissingLocaleError(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, errs.CodeInvalidOption, ErrMissingLocale.Code())
+	require.True(t, errors.Is(ErrMissingLocale, errs.ErrConstraint))
+}
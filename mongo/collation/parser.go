@@ -0,0 +1,251 @@
+package collation
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+	"github.com/StevenCyb/go-mongo-tools/tokenizer"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Types that are used in this parser.
+const (
+	SkipType   tokenizer.Type = "SKIP"
+	AndType    tokenizer.Type = ";"
+	SetType    tokenizer.Type = "="
+	OptionType tokenizer.Type = "OPTION"
+	ValueType  tokenizer.Type = "VALUE"
+)
+
+// Options of a collation.
+const (
+	LocaleOption          = "locale"
+	CaseLevelOption       = "caseLevel"
+	CaseFirstOption       = "caseFirst"
+	StrengthOption        = "strength"
+	NumericOrderingOption = "numericOrdering"
+	AlternateOption       = "alternate"
+	MaxVariableOption     = "maxVariable"
+	NormalizationOption   = "normalization"
+	BackwardsOption       = "backwards"
+)
+
+// localePattern matches the simple binary comparison and ICU locales like `de`, `de_AT`,
+// `zh_Hant`, `en_US_POSIX` or `de@collation=phonebook`.
+//
+//nolint:gochecknoglobals
+var localePattern = regexp.MustCompile(`^(simple|[a-z]{2,3}(_[A-Z][a-z]{3})?(_[A-Z]{2}(_[A-Z]+)?)?(@collation=[a-z]+)?)$`)
+
+// NewParser creates a new parser that only allows locales allowed by given policy.
+func NewParser(localePolicy tokenizer.FieldPolicy) *Parser {
+	return &Parser{
+		localePolicy: localePolicy,
+	}
+}
+
+// Parser provides the logic to parse collations.
+// The locale policy is its only state, so one parser can serve all requests.
+type Parser struct {
+	localePolicy tokenizer.FieldPolicy
+}
+
+// parseState holds the state of a single parsing.
+type parseState struct {
+	*Parser
+	ctx       context.Context //nolint:containedctx
+	tokenizer *tokenizer.Tokenizer
+	lookahead *tokenizer.Token
+	options   map[string]bool
+	collation *options.Collation
+}
+
+// eat return a token with expected type.
+func (p *parseState) eat(tokenType tokenizer.Type) (*tokenizer.Token, error) {
+	token := p.lookahead
+
+	if token == nil || token.Type != tokenType {
+		return nil, p.tokenizer.Unexpected(p.lookahead, tokenType)
+	}
+
+	return token, p.next()
+}
+
+// next fetches the next token as lookahead.
+func (p *parseState) next() error {
+	var err error
+
+	p.lookahead, err = p.tokenizer.GetNextToken()
+
+	return err //nolint:wrapcheck
+}
+
+// Parse a given query.
+func (p *Parser) Parse(query string) (*options.Collation, error) {
+	return p.ParseContext(context.Background(), query)
+}
+
+// ParseContext parses a given query and passes the context to the locale policy.
+// An empty query results in no collation.
+func (p *Parser) ParseContext(ctx context.Context, query string) (*options.Collation, error) {
+	if query == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	state := &parseState{
+		Parser:    p,
+		ctx:       ctx,
+		tokenizer: tokenizer.NewScannerTokenizer(query, SkipType, OptionType, scan, nil).WithContext(ctx),
+		options:   map[string]bool{},
+		collation: &options.Collation{},
+	}
+
+	err := state.next()
+	if err != nil {
+		return nil, err
+	}
+
+	err = state.expression()
+	if err != nil {
+		return nil, err
+	}
+
+	if state.collation.Locale == "" {
+		return nil, ErrMissingLocale
+	}
+
+	return state.collation, nil
+}
+
+/*
+ * <expression>
+ *   | <statement>
+ *   | <statement> ";" <expression>
+ * .
+ */
+func (p *parseState) expression() error {
+	for {
+		err := p.statement()
+		if err != nil {
+			return err
+		}
+
+		if p.lookahead == nil {
+			return nil
+		}
+
+		_, err = p.eat(AndType)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+/*
+ * <statement>
+ *   : <option> "=" <value>
+ * .
+ */
+func (p *parseState) statement() error {
+	optionToken, err := p.eat(OptionType)
+	if err != nil {
+		return err
+	}
+
+	if p.options[optionToken.Value] {
+		return DuplicateOptionError{Diagnostic: p.diagnostic(optionToken)}
+	}
+
+	p.options[optionToken.Value] = true
+
+	_, err = p.eat(SetType)
+	if err != nil {
+		return err
+	}
+
+	valueToken, err := p.eat(ValueType)
+	if err != nil {
+		return err
+	}
+
+	return p.set(optionToken, valueToken)
+}
+
+// set validates the value of an option and sets it on the collation.
+func (p *parseState) set(optionToken, valueToken *tokenizer.Token) error {
+	var err error
+
+	switch optionToken.Value {
+	case LocaleOption:
+		err = p.locale(valueToken)
+	case CaseLevelOption:
+		p.collation.CaseLevel, err = p.boolean(optionToken, valueToken)
+	case CaseFirstOption:
+		p.collation.CaseFirst, err = p.oneOf(optionToken, valueToken, "upper", "lower", "off")
+	case StrengthOption:
+		p.collation.Strength, err = p.strength(optionToken, valueToken)
+	case NumericOrderingOption:
+		p.collation.NumericOrdering, err = p.boolean(optionToken, valueToken)
+	case AlternateOption:
+		p.collation.Alternate, err = p.oneOf(optionToken, valueToken, "non-ignorable", "shifted")
+	case MaxVariableOption:
+		p.collation.MaxVariable, err = p.oneOf(optionToken, valueToken, "punct", "space")
+	case NormalizationOption:
+		p.collation.Normalization, err = p.boolean(optionToken, valueToken)
+	case BackwardsOption:
+		p.collation.Backwards, err = p.boolean(optionToken, valueToken)
+	default:
+		err = UnknownOptionError{Diagnostic: p.diagnostic(optionToken)}
+	}
+
+	return err
+}
+
+// locale sets the locale if it is well-formed and allowed by the policy.
+func (p *parseState) locale(valueToken *tokenizer.Token) error {
+	if !localePattern.MatchString(valueToken.Value) {
+		return InvalidOptionError{Diagnostic: p.diagnostic(valueToken), option: LocaleOption}
+	}
+
+	if p.localePolicy != nil && !p.localePolicy.AllowContext(p.ctx, valueToken.Value) {
+		return errs.NewErrPolicyViolationWithDiagnostic(p.diagnostic(valueToken))
+	}
+
+	p.collation.Locale = valueToken.Value
+
+	return nil
+}
+
+// boolean returns the value of a boolean option.
+func (p *parseState) boolean(optionToken, valueToken *tokenizer.Token) (bool, error) {
+	value, err := p.oneOf(optionToken, valueToken, "true", "false")
+
+	return value == "true", err
+}
+
+// strength returns the comparison level from 1 (base characters) to 5 (identical).
+func (p *parseState) strength(optionToken, valueToken *tokenizer.Token) (int, error) {
+	value, err := p.oneOf(optionToken, valueToken, "1", "2", "3", "4", "5")
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(value) //nolint:wrapcheck
+}
+
+// oneOf returns the value if it is one of the allowed values of the option.
+func (p *parseState) oneOf(optionToken, valueToken *tokenizer.Token, allowed ...string) (string, error) {
+	for _, value := range allowed {
+		if valueToken.Value == value {
+			return value, nil
+		}
+	}
+
+	return "", InvalidOptionError{Diagnostic: p.diagnostic(valueToken, allowed...), option: optionToken.Value}
+}
+
+// diagnostic returns the diagnostic of given token.
+func (p *parseState) diagnostic(token *tokenizer.Token, expected ...string) errs.Diagnostic {
+	return errs.NewDiagnostic(p.tokenizer.GetQuery(), token.Start, len(token.Value), token.Value, expected...)
+}
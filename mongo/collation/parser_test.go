@@ -0,0 +1,124 @@
+//nolint:funlen
+package collation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+	"github.com/StevenCyb/go-mongo-tools/tokenizer"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestParsing(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Query", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("WithEmptyQuery_Success", func(t *testing.T) {
+			t.Parallel()
+
+			collation, err := NewParser(nil).Parse("")
+			require.NoError(t, err)
+			require.Nil(t, collation)
+		})
+
+		t.Run("WithLocale_Success", func(t *testing.T) {
+			t.Parallel()
+
+			for _, locale := range []string{"simple", "de", "de_AT", "zh_Hant", "en_US_POSIX", "de@collation=phonebook"} {
+				collation, err := NewParser(nil).Parse("locale=" + locale)
+				require.NoError(t, err, locale)
+				require.Equal(t, &options.Collation{Locale: locale}, collation)
+			}
+		})
+
+		t.Run("WithAllOptions_Success", func(t *testing.T) {
+			t.Parallel()
+
+			collation, err := NewParser(nil).Parse("locale=tr; strength=2;caseLevel=true;caseFirst=upper;" +
+				"numericOrdering=true;alternate=shifted;maxVariable=space;normalization=true;backwards=false")
+			require.NoError(t, err)
+			require.Equal(t, &options.Collation{
+				Locale:          "tr",
+				Strength:        2,
+				CaseLevel:       true,
+				CaseFirst:       "upper",
+				NumericOrdering: true,
+				Alternate:       "shifted",
+				MaxVariable:     "space",
+				Normalization:   true,
+			}, collation)
+		})
+
+		t.Run("WithMissingLocale_Fail", func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewParser(nil).Parse("strength=1")
+			require.True(t, errors.Is(err, ErrMissingLocale))
+		})
+
+		t.Run("WithInvalidValue_Fail", func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewParser(nil).Parse("locale=de;strength=6")
+			require.Equal(t, InvalidOptionError{
+				Diagnostic: errs.NewDiagnostic("locale=de;strength=6", 19, 1, "6", "1", "2", "3", "4", "5"),
+				option:     StrengthOption,
+			}, err)
+
+			_, err = NewParser(nil).Parse("locale=DE")
+			require.Equal(t, InvalidOptionError{
+				Diagnostic: errs.NewDiagnostic("locale=DE", 7, 2, "DE"),
+				option:     LocaleOption,
+			}, err)
+		})
+
+		t.Run("WithUnknownOption_Fail", func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewParser(nil).Parse("locale=de;Strength=1")
+			require.Equal(t, UnknownOptionError{Diagnostic: errs.NewDiagnostic("locale=de;Strength=1", 10, 8, "Strength")}, err)
+		})
+
+		t.Run("WithDuplicateOption_Fail", func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewParser(nil).Parse("locale=de;locale=tr")
+			require.Equal(t, DuplicateOptionError{Diagnostic: errs.NewDiagnostic("locale=de;locale=tr", 10, 6, "locale")}, err)
+		})
+
+		t.Run("WithInvalidSyntax_Fail", func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewParser(nil).Parse("locale=de;")
+			require.Equal(t, errs.NewErrUnexpectedInputEndWithDiagnostic(errs.NewDiagnostic(
+				"locale=de;", 10, 0, "", "OPTION")), err)
+
+			_, err = NewParser(nil).Parse("locale=de strength=1")
+			require.Equal(t, errs.NewErrUnexpectedTokenTypeWithDiagnostic(errs.NewDiagnostic(
+				"locale=de strength=1", 10, 8, "OPTION", ";")), err)
+
+			_, err = NewParser(nil).Parse("locale==de")
+			require.Equal(t, errs.NewErrUnexpectedTokenTypeWithDiagnostic(errs.NewDiagnostic(
+				"locale==de", 7, 1, "=", "VALUE")), err)
+		})
+	})
+
+	t.Run("WithPolicy", func(t *testing.T) {
+		t.Parallel()
+
+		parser := NewParser(tokenizer.NewPolicy(tokenizer.WhitelistPolicy, "de", "tr"))
+
+		collation, err := parser.ParseContext(context.Background(), "locale=de;strength=1")
+		require.NoError(t, err)
+		require.Equal(t, &options.Collation{Locale: "de", Strength: 1}, collation)
+
+		_, err = parser.Parse("strength=1;locale=fr")
+		require.Equal(t, errs.NewErrPolicyViolationWithDiagnostic(errs.NewDiagnostic(
+			"strength=1;locale=fr", 18, 2, "fr")), err)
+	})
+}
@@ -0,0 +1,40 @@
+package collation
+
+import (
+	"github.com/StevenCyb/go-mongo-tools/tokenizer"
+)
+
+// scan returns the collation token at the start of given input.
+// Letters followed by `=` are an option, so values like `de@collation=phonebook` can contain `=`.
+func scan(input string) (tokenizer.Type, int) {
+	if length := tokenizer.ScanSpace(input); length > 0 {
+		return SkipType, length
+	}
+
+	length := 0
+
+	switch input[0] {
+	case ';':
+		return AndType, 1
+	case '=':
+		return SetType, 1
+	}
+
+	for length < len(input) && tokenizer.IsLetter(input[length]) {
+		length++
+	}
+
+	if length > 0 && length < len(input) && input[length] == '=' {
+		return OptionType, length
+	}
+
+	for length < len(input) && !isDelimiter(input[length]) {
+		length++
+	}
+
+	return ValueType, length
+}
+
+func isDelimiter(character byte) bool {
+	return character == ';' || tokenizer.IsSpace(character)
+}
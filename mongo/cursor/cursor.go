@@ -0,0 +1,275 @@
+package cursor
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TieBreaker is the key that is appended to sorts to make them unique.
+const TieBreaker = "_id"
+
+// Direction of paging relative to the document of a cursor.
+type Direction int
+
+const (
+	// Forward pages to the documents after the cursor.
+	Forward Direction = iota
+	// Backward pages to the documents before the cursor.
+	Backward
+)
+
+// payload is the signed content of a cursor token.
+type payload struct {
+	Keys      []string  `bson:"k"`
+	Orders    []int     `bson:"o"`
+	Values    bson.A    `bson:"v"`
+	Direction Direction `bson:"d"`
+}
+
+// Page describes the query of the page that a cursor points to.
+type Page struct {
+	// Filter selects the documents after (or before) the cursor and must be combined with other filters using `$and`.
+	Filter bson.D
+	// Sort is the sort to query the page with. It is reversed for `Backward`,
+	// so the documents of the page must be reversed after querying.
+	Sort bson.D
+	// Direction is the direction of paging.
+	Direction Direction
+}
+
+// NewCodec creates a new codec that signs cursors with given secret.
+func NewCodec(secret []byte) (*Codec, error) {
+	if len(secret) == 0 {
+		return nil, ErrEmptySecret
+	}
+
+	return &Codec{secret: append([]byte{}, secret...)}, nil
+}
+
+// Codec encodes documents into opaque cursor tokens and decodes them into keyset filters.
+// A codec is safe for concurrent use.
+type Codec struct {
+	secret []byte
+}
+
+// WithTieBreaker returns given sort with `_id` appended if not already part of it.
+func WithTieBreaker(sort bson.D) bson.D {
+	for _, element := range sort {
+		if element.Key == TieBreaker {
+			return sort
+		}
+	}
+
+	return append(append(bson.D{}, sort...), bson.E{Key: TieBreaker, Value: 1})
+}
+
+// Encode creates a cursor token for given sort that points to given document,
+// which is usually the last (or first for `Backward`) document of the current page.
+func (c *Codec) Encode(sort bson.D, document interface{}, direction Direction) (string, error) {
+	sort = WithTieBreaker(sort)
+
+	raw, err := bson.Marshal(document)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal document: %w", err)
+	}
+
+	content := payload{
+		Keys:      make([]string, 0, len(sort)),
+		Orders:    make([]int, 0, len(sort)),
+		Values:    make(bson.A, 0, len(sort)),
+		Direction: direction,
+	}
+
+	for _, element := range sort {
+		order, err := orderOf(element)
+		if err != nil {
+			return "", err
+		}
+
+		var value interface{}
+
+		lookup, err := bson.Raw(raw).LookupErr(strings.Split(element.Key, ".")...)
+		if err == nil {
+			value = lookup
+		} else if element.Key == TieBreaker {
+			return "", MissingFieldError{field: TieBreaker}
+		}
+
+		content.Keys = append(content.Keys, element.Key)
+		content.Orders = append(content.Orders, order)
+		content.Values = append(content.Values, value)
+	}
+
+	data, err := bson.Marshal(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal cursor: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data) + "." +
+		base64.RawURLEncoding.EncodeToString(c.sign(data)), nil
+}
+
+// Decode verifies given cursor token for given sort and returns the query of the page it points to.
+func (c *Codec) Decode(token string, sort bson.D) (Page, error) {
+	sort = WithTieBreaker(sort)
+
+	encodedData, encodedSignature, found := strings.Cut(token, ".")
+	if !found {
+		return Page{}, ErrInvalidToken
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encodedData)
+	if err != nil {
+		return Page{}, ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return Page{}, ErrInvalidToken
+	}
+
+	if !hmac.Equal(signature, c.sign(data)) {
+		return Page{}, ErrTamperedToken
+	}
+
+	content := payload{}
+	if err := bson.Unmarshal(data, &content); err != nil {
+		return Page{}, ErrInvalidToken
+	}
+
+	if err := content.match(sort); err != nil {
+		return Page{}, err
+	}
+
+	return Page{
+		Filter:    content.filter(),
+		Sort:      content.sort(),
+		Direction: content.Direction,
+	}, nil
+}
+
+// sign returns the signature of given data.
+func (c *Codec) sign(data []byte) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write(data)
+
+	return mac.Sum(nil)
+}
+
+// match checks that the cursor was created for given sort.
+func (p payload) match(sort bson.D) error {
+	if len(p.Keys) != len(sort) || len(p.Orders) != len(sort) || len(p.Values) != len(sort) {
+		return ErrSortMismatch
+	}
+
+	for i, element := range sort {
+		order, err := orderOf(element)
+		if err != nil {
+			return err
+		}
+
+		if p.Keys[i] != element.Key || p.Orders[i] != order {
+			return ErrSortMismatch
+		}
+	}
+
+	return nil
+}
+
+/*
+ * (k1 > v1) or (k1 = v1 and k2 > v2) or ... (k1 = v1 and ... and kn > vn)
+ * where ">" is "<" for descending keys and both are swapped for `Backward`.
+ */
+func (p payload) filter() bson.D {
+	alternatives := make(bson.A, 0, len(p.Keys))
+
+	for i := range p.Keys {
+		after, exists := p.after(i)
+		if !exists {
+			continue
+		}
+
+		alternative := bson.D{}
+
+		for j := 0; j < i; j++ {
+			alternative = append(alternative, bson.E{Key: p.Keys[j], Value: p.Values[j]})
+		}
+
+		alternatives = append(alternatives, append(alternative, after))
+	}
+
+	return bson.D{{Key: "$or", Value: alternatives}}
+}
+
+// after returns the condition for values of the i-th key that follow the value of the cursor.
+// MongoDB only compares values of the same type and sorts null and missing values first,
+// so they are selected explicitly instead of by `$gt` or `$lt`. The tie-breaker is never null.
+func (p payload) after(i int) (bson.E, bool) {
+	var (
+		key       = p.Keys[i]
+		value     = p.Values[i]
+		ascending = (p.Orders[i] > 0) != (p.Direction == Backward)
+	)
+
+	switch {
+	case value == nil && ascending:
+		return bson.E{Key: key, Value: bson.D{{Key: "$ne", Value: nil}}}, true
+	case value == nil:
+		return bson.E{}, false
+	case ascending:
+		return bson.E{Key: key, Value: bson.D{{Key: "$gt", Value: value}}}, true
+	case key == TieBreaker:
+		return bson.E{Key: key, Value: bson.D{{Key: "$lt", Value: value}}}, true
+	}
+
+	return bson.E{Key: "$or", Value: bson.A{
+		bson.D{{Key: key, Value: bson.D{{Key: "$lt", Value: value}}}},
+		bson.D{{Key: key, Value: nil}},
+	}}, true
+}
+
+// sort returns the sort to query the page, reversed for `Backward`.
+func (p payload) sort() bson.D {
+	sort := make(bson.D, 0, len(p.Keys))
+
+	for i, key := range p.Keys {
+		order := p.Orders[i]
+		if p.Direction == Backward {
+			order = -order
+		}
+
+		sort = append(sort, bson.E{Key: key, Value: order})
+	}
+
+	return sort
+}
+
+// orderOf returns the sort order of given element as `1` or `-1`.
+func orderOf(element bson.E) (int, error) {
+	var order int64
+
+	switch value := element.Value.(type) {
+	case int:
+		order = int64(value)
+	case int32:
+		order = int64(value)
+	case int64:
+		order = value
+	case float64:
+		order = int64(value)
+	default:
+		return 0, InvalidOrderError{field: element.Key, value: element.Value}
+	}
+
+	switch order {
+	case 1, -1:
+		return int(order), nil
+	}
+
+	return 0, InvalidOrderError{field: element.Key, value: element.Value}
+}
@@ -0,0 +1,469 @@
+//nolint:funlen
+package cursor
+
+import (
+	"context"
+	"runtime"
+	stdsort "sort"
+	"strings"
+	"testing"
+
+	"github.com/StevenCyb/go-mongo-tools/mongo/sort"
+	testutil "github.com/StevenCyb/go-mongo-tools/mongo/test_util"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func newTestCodec(t *testing.T) *Codec {
+	t.Helper()
+
+	codec, err := NewCodec([]byte("secret"))
+	require.NoError(t, err)
+
+	return codec
+}
+
+func TestWithTieBreaker(t *testing.T) {
+	t.Parallel()
+
+	sort := bson.D{{Key: "age", Value: -1}}
+	require.Equal(t, bson.D{{Key: "age", Value: -1}, {Key: "_id", Value: 1}}, WithTieBreaker(sort))
+	require.Equal(t, bson.D{{Key: "age", Value: -1}}, sort)
+
+	sort = bson.D{{Key: "_id", Value: -1}, {Key: "age", Value: 1}}
+	require.Equal(t, sort, WithTieBreaker(sort))
+}
+
+func TestCodec(t *testing.T) {
+	t.Parallel()
+
+	sortExpression, err := sort.NewParser(nil).Parse("age=desc,address.city=asc")
+	require.NoError(t, err)
+
+	id := primitive.NewObjectID()
+	document := bson.M{"_id": id, "age": 42, "address": bson.M{"city": "Berlin"}}
+
+	t.Run("Forward_Success", func(t *testing.T) {
+		t.Parallel()
+
+		codec := newTestCodec(t)
+
+		token, err := codec.Encode(sortExpression, document, Forward)
+		require.NoError(t, err)
+
+		page, err := codec.Decode(token, sortExpression)
+		require.NoError(t, err)
+		require.Equal(t, Page{
+			Filter: bson.D{{Key: "$or", Value: bson.A{
+				bson.D{{Key: "$or", Value: bson.A{
+					bson.D{{Key: "age", Value: bson.D{{Key: "$lt", Value: int32(42)}}}},
+					bson.D{{Key: "age", Value: nil}},
+				}}},
+				bson.D{
+					{Key: "age", Value: int32(42)},
+					{Key: "address.city", Value: bson.D{{Key: "$gt", Value: "Berlin"}}},
+				},
+				bson.D{
+					{Key: "age", Value: int32(42)},
+					{Key: "address.city", Value: "Berlin"},
+					{Key: "_id", Value: bson.D{{Key: "$gt", Value: id}}},
+				},
+			}}},
+			Sort:      bson.D{{Key: "age", Value: -1}, {Key: "address.city", Value: 1}, {Key: "_id", Value: 1}},
+			Direction: Forward,
+		}, page)
+	})
+
+	t.Run("Backward_Success", func(t *testing.T) {
+		t.Parallel()
+
+		codec := newTestCodec(t)
+
+		token, err := codec.Encode(sortExpression, document, Backward)
+		require.NoError(t, err)
+
+		page, err := codec.Decode(token, sortExpression)
+		require.NoError(t, err)
+		require.Equal(t, Page{
+			Filter: bson.D{{Key: "$or", Value: bson.A{
+				bson.D{{Key: "age", Value: bson.D{{Key: "$gt", Value: int32(42)}}}},
+				bson.D{
+					{Key: "age", Value: int32(42)},
+					{Key: "$or", Value: bson.A{
+						bson.D{{Key: "address.city", Value: bson.D{{Key: "$lt", Value: "Berlin"}}}},
+						bson.D{{Key: "address.city", Value: nil}},
+					}},
+				},
+				bson.D{
+					{Key: "age", Value: int32(42)},
+					{Key: "address.city", Value: "Berlin"},
+					{Key: "_id", Value: bson.D{{Key: "$lt", Value: id}}},
+				},
+			}}},
+			Sort:      bson.D{{Key: "age", Value: 1}, {Key: "address.city", Value: -1}, {Key: "_id", Value: -1}},
+			Direction: Backward,
+		}, page)
+	})
+
+	t.Run("WithMissingField_Success", func(t *testing.T) {
+		t.Parallel()
+
+		codec := newTestCodec(t)
+
+		token, err := codec.Encode(sortExpression, bson.M{"_id": id, "age": 42}, Forward)
+		require.NoError(t, err)
+
+		page, err := codec.Decode(token, sortExpression)
+		require.NoError(t, err)
+		require.Equal(t, bson.D{
+			{Key: "age", Value: int32(42)},
+			{Key: "address.city", Value: bson.D{{Key: "$ne", Value: nil}}},
+		}, page.Filter[0].Value.(bson.A)[1])
+
+		token, err = codec.Encode(bson.D{{Key: "address.city", Value: -1}}, bson.M{"_id": id}, Forward)
+		require.NoError(t, err)
+
+		page, err = codec.Decode(token, bson.D{{Key: "address.city", Value: -1}})
+		require.NoError(t, err)
+		require.Equal(t, bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: "address.city", Value: nil}, {Key: "_id", Value: bson.D{{Key: "$gt", Value: id}}}},
+		}}}, page.Filter)
+	})
+
+	t.Run("WithEmptySecret_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewCodec(nil)
+		require.ErrorIs(t, err, ErrEmptySecret)
+	})
+
+	t.Run("WithMissingTieBreaker_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newTestCodec(t).Encode(sortExpression, bson.M{"age": 42}, Forward)
+		require.Equal(t, MissingFieldError{field: "_id"}, err)
+	})
+
+	t.Run("WithInvalidOrder_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newTestCodec(t).Encode(bson.D{{Key: "age", Value: 2}}, document, Forward)
+		require.Equal(t, InvalidOrderError{field: "age", value: 2}, err)
+	})
+
+	t.Run("WithInvalidToken_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		codec := newTestCodec(t)
+
+		for _, token := range []string{"", "abc", "a.b", "!.!", "YQ.!"} {
+			_, err := codec.Decode(token, sortExpression)
+			require.ErrorIs(t, err, ErrInvalidToken, token)
+		}
+	})
+
+	t.Run("WithTamperedToken_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		codec := newTestCodec(t)
+
+		token, err := codec.Encode(sortExpression, document, Forward)
+		require.NoError(t, err)
+
+		other, err := NewCodec([]byte("other"))
+		require.NoError(t, err)
+
+		_, err = other.Decode(token, sortExpression)
+		require.ErrorIs(t, err, ErrTamperedToken)
+
+		data, signature, _ := strings.Cut(token, ".")
+		tampered := "B"
+		if data[10:11] == tampered {
+			tampered = "C"
+		}
+
+		_, err = codec.Decode(data[:10]+tampered+data[11:]+"."+signature, sortExpression)
+		require.ErrorIs(t, err, ErrTamperedToken)
+	})
+
+	t.Run("WithDifferentSort_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		codec := newTestCodec(t)
+
+		token, err := codec.Encode(sortExpression, document, Forward)
+		require.NoError(t, err)
+
+		_, err = codec.Decode(token, bson.D{{Key: "age", Value: 1}, {Key: "address.city", Value: 1}})
+		require.ErrorIs(t, err, ErrSortMismatch)
+
+		_, err = codec.Decode(token, bson.D{{Key: "age", Value: -1}})
+		require.ErrorIs(t, err, ErrSortMismatch)
+	})
+}
+
+// matches evaluates the subset of filters created by the codec on given document
+// following MongoDB, which only compares values of the same type and matches null equalities on missing fields.
+func matches(filter bson.D, document bson.M) bool {
+	for _, element := range filter {
+		if element.Key == "$or" {
+			matched := false
+
+			for _, alternative := range element.Value.(bson.A) {
+				matched = matched || matches(alternative.(bson.D), document)
+			}
+
+			if !matched {
+				return false
+			}
+
+			continue
+		}
+
+		value := document[element.Key]
+		condition, isCondition := element.Value.(bson.D)
+
+		if !isCondition {
+			if !equal(value, element.Value) {
+				return false
+			}
+
+			continue
+		}
+
+		result, comparable := compare(value, condition[0].Value)
+
+		switch condition[0].Key {
+		case "$ne":
+			if equal(value, condition[0].Value) {
+				return false
+			}
+		case "$gt":
+			if !comparable || value == nil || result <= 0 {
+				return false
+			}
+		case "$lt":
+			if !comparable || value == nil || result >= 0 {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// equal reports whether two values of the test documents are equal.
+func equal(a, b interface{}) bool {
+	result, comparable := compare(a, b)
+
+	return comparable && result == 0
+}
+
+// compare compares the values of the test documents, null is lower than all other values.
+func compare(a, b interface{}) (int, bool) {
+	switch {
+	case a == nil && b == nil:
+		return 0, true
+	case a == nil:
+		return -1, true
+	case b == nil:
+		return 1, true
+	}
+
+	switch a := a.(type) {
+	case int32:
+		other, ok := b.(int32)
+
+		return int(a - other), ok
+	case string:
+		other, ok := b.(string)
+
+		return strings.Compare(a, other), ok
+	}
+
+	return 0, false
+}
+
+// sortDocuments sorts the documents like MongoDB for given sort.
+func sortDocuments(documents []bson.M, sort bson.D) {
+	stdsort.SliceStable(documents, func(i, j int) bool {
+		for _, element := range sort {
+			if result, _ := compare(documents[i][element.Key], documents[j][element.Key]); result != 0 {
+				return (result < 0) == (element.Value.(int) > 0)
+			}
+		}
+
+		return false
+	})
+}
+
+func TestPaging(t *testing.T) {
+	t.Parallel()
+
+	for _, sortQuery := range []string{"age=desc,name=asc", "name=asc,age=desc", "name=desc,age=asc"} {
+		sortQuery := sortQuery
+
+		t.Run(sortQuery, func(t *testing.T) {
+			t.Parallel()
+
+			sortExpression, err := sort.NewParser(nil).Parse(sortQuery)
+			require.NoError(t, err)
+
+			documents := pagingDocuments()
+			query := func(filter, sort bson.D, limit int) []bson.M {
+				selected := []bson.M{}
+
+				for _, document := range documents {
+					if matches(filter, document) {
+						selected = append(selected, document)
+					}
+				}
+
+				sortDocuments(selected, sort)
+
+				if len(selected) > limit {
+					selected = selected[:limit]
+				}
+
+				return selected
+			}
+
+			expected := query(bson.D{}, WithTieBreaker(sortExpression), len(documents))
+			requirePaging(t, newTestCodec(t), sortExpression, expected, query)
+		})
+	}
+}
+
+// pagingDocuments returns documents with duplicate, null and missing values.
+func pagingDocuments() []bson.M {
+	documents := []bson.M{}
+
+	for i, name := range []interface{}{"d", "b", nil, "a", "c", "b", "", "a", "e", nil, "b", "c", "", "a"} {
+		document := bson.M{"_id": int32(i), "age": int32(20 + i%3)}
+
+		switch {
+		case name == "":
+		case name == nil:
+			document["name"] = nil
+		default:
+			document["name"] = name
+		}
+
+		documents = append(documents, document)
+	}
+
+	return documents
+}
+
+// requirePaging pages forward and backward through the documents of given query and compares them with expected.
+func requirePaging(
+	t *testing.T, codec *Codec, sortExpression bson.D, expected []bson.M,
+	query func(filter, sort bson.D, limit int) []bson.M,
+) {
+	t.Helper()
+
+	const pageSize = 3
+
+	page := func(token string, direction Direction) []bson.M {
+		if token == "" {
+			return query(bson.D{}, WithTieBreaker(sortExpression), pageSize)
+		}
+
+		decoded, err := codec.Decode(token, sortExpression)
+		require.NoError(t, err)
+		require.Equal(t, direction, decoded.Direction)
+
+		selected := query(decoded.Filter, decoded.Sort, pageSize)
+
+		if direction == Backward {
+			for i, j := 0, len(selected)-1; i < j; i, j = i+1, j-1 {
+				selected[i], selected[j] = selected[j], selected[i]
+			}
+		}
+
+		return selected
+	}
+
+	forward := []bson.M{}
+	pages := [][]bson.M{}
+
+	for token := ""; ; {
+		current := page(token, Forward)
+		if len(current) == 0 {
+			break
+		}
+
+		forward = append(forward, current...)
+		pages = append(pages, current)
+
+		var err error
+
+		token, err = codec.Encode(sortExpression, current[len(current)-1], Forward)
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, expected, forward)
+	require.Len(t, pages, (len(expected)+pageSize-1)/pageSize)
+
+	for i := len(pages) - 1; i > 0; i-- {
+		token, err := codec.Encode(sortExpression, pages[i][0], Backward)
+		require.NoError(t, err)
+		require.Equal(t, pages[i-1], page(token, Backward))
+	}
+}
+
+func TestInterpretation(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "darwin" {
+		t.Skip("Not running on darwin")
+	}
+
+	ctx := context.Background()
+	server := testutil.NewStrikemongoServer(t)
+	mongoClient, collection, database := testutil.NewClientWithCollection(t, server)
+
+	//nolint:errcheck
+	t.Cleanup(func() {
+		server.Stop()
+		mongoClient.Disconnect(ctx)
+		database.Drop(ctx)
+	})
+
+	documents := pagingDocuments()
+
+	items := []interface{}{}
+	for _, document := range documents {
+		items = append(items, document)
+	}
+
+	testutil.Populate(t, collection, items)
+
+	query := func(filter, sort bson.D, limit int) []bson.M {
+		cur, err := collection.Find(ctx, filter, options.Find().SetSort(sort).SetLimit(int64(limit)))
+		require.NoError(t, err)
+
+		selected := []bson.M{}
+		require.NoError(t, cur.All(ctx, &selected))
+
+		return selected
+	}
+
+	for _, sortQuery := range []string{"age=desc,name=asc", "name=asc,age=desc", "name=desc,age=asc"} {
+		sortQuery := sortQuery
+
+		t.Run(sortQuery, func(t *testing.T) {
+			t.Parallel()
+
+			sortExpression, err := sort.NewParser(nil).Parse(sortQuery)
+			require.NoError(t, err)
+
+			expected := append([]bson.M{}, documents...)
+			sortDocuments(expected, WithTieBreaker(sortExpression))
+
+			requirePaging(t, newTestCodec(t), sortExpression, expected, query)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package cursor
+
+import (
+	"fmt"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+)
+
+var (
+	ErrEmptySecret   = errs.NewErrSentinel(errs.ErrConstraint, errs.CodeInvalidConfiguration, "secret is empty")
+	ErrInvalidToken  = errs.NewErrSentinel(errs.ErrSyntax, errs.CodeInvalidCursor, "cursor is malformed")
+	ErrTamperedToken = errs.NewErrSentinel(errs.ErrConstraint, errs.CodeInvalidCursor, "cursor signature is invalid")
+	ErrSortMismatch  = errs.NewErrSentinel(errs.ErrConstraint, errs.CodeInvalidCursor, "cursor was created for a different sort")
+)
+
+// MissingFieldError indicate that a document has no value for a required sort key.
+type MissingFieldError struct {
+	field string
+}
+
+func (m MissingFieldError) Error() string {
+	return fmt.Sprintf("document has no field '%s'", m.field)
+}
+
+// Path returns the missing field.
+func (m MissingFieldError) Path() string {
+	return m.field
+}
+
+// Code returns the error code.
+func (m MissingFieldError) Code() string {
+	return errs.CodeUnknownField
+}
+
+// Details returns the parameters of the error.
+func (m MissingFieldError) Details() map[string]interface{} {
+	return map[string]interface{}{"field": m.field}
+}
+
+// Is reports whether the error belongs to given category.
+func (m MissingFieldError) Is(target error) bool {
+	return target == errs.ErrUnknown
+}
+
+// InvalidOrderError indicate that a sort key has an order other than `1` or `-1`.
+type InvalidOrderError struct {
+	value interface{}
+	field string
+}
+
+func (i InvalidOrderError) Error() string {
+	return fmt.Sprintf("sort order '%v' of '%s' is invalid, must be 1 or -1", i.value, i.field)
+}
+
+// Path returns the field with the invalid order.
+func (i InvalidOrderError) Path() string {
+	return i.field
+}
+
+// Value returns the invalid order.
+func (i InvalidOrderError) Value() interface{} {
+	return i.value
+}
+
+// Code returns the error code.
+func (i InvalidOrderError) Code() string {
+	return errs.CodeTypeMismatch
+}
+
+// Details returns the parameters of the error.
+func (i InvalidOrderError) Details() map[string]interface{} {
+	return map[string]interface{}{"field": i.field, "value": i.value}
+}
+
+// Is reports whether the error belongs to given category.
+func (i InvalidOrderError) Is(target error) bool {
+	return target == errs.ErrType
+}
@@ -0,0 +1,39 @@
+package cursor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMissingFieldError(t *testing.T) {
+	t.Parallel()
+
+	err := MissingFieldError{field: "_id"}
+	require.Equal(t, "document has no field '_id'", err.Error())
+	require.Equal(t, "_id", err.Path())
+	require.True(t, errors.Is(err, errs.ErrUnknown))
+}
+
+func TestInvalidOrderError(t *testing.T) {
+	t.Parallel()
+
+	err := InvalidOrderError{field: "age", value: "up"}
+	require.Equal(t, "sort order 'up' of 'age' is invalid, must be 1 or -1", err.Error())
+	require.Equal(t, "up", err.Value())
+	require.True(t, errors.Is(err, errs.ErrType))
+}
+
+func TestSentinelErrors(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, errors.Is(ErrInvalidToken, errs.ErrSyntax))
+	require.True(t, errors.Is(ErrTamperedToken, errs.ErrConstraint))
+	require.True(t, errors.Is(ErrSortMismatch, errs.ErrConstraint))
+	require.Equal(t, errs.CodeInvalidCursor, ErrInvalidToken.Code())
+	require.False(t, errors.Is(ErrTamperedToken, ErrInvalidToken))
+	require.Equal(t, errs.CodeInvalidConfiguration, ErrEmptySecret.Code())
+	require.True(t, errors.Is(ErrEmptySecret, errs.ErrConstraint))
+}
@@ -0,0 +1,259 @@
+package index
+
+import (
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Report of the index usage of a query.
+type Report struct {
+	// Index is the name of the best index, empty if none or the query uses `Branches`.
+	Index string
+	// Filter reports whether an index bounds the filter, always true for empty filters.
+	Filter bool
+	// Sort reports whether an index returns the sort order without in-memory sort, always true for empty sorts.
+	Sort bool
+	// Keys is the number of leading index keys that bound the filter.
+	Keys int
+	// Branches are the reports of the branches of an `$or` that is bounded by an index per branch.
+	Branches []Report
+}
+
+// Usable reports whether an index supports the filter and the sort.
+func (r Report) Usable() bool {
+	return r.Filter && r.Sort
+}
+
+// NewChecker creates a new checker for given index definitions.
+// The index on `_id` is always added, partial and wildcard indexes are ignored
+// since their usability depends on the values of a query.
+func NewChecker(models ...mongo.IndexModel) (*Checker, error) {
+	checker := &Checker{
+		indexes: []index{{name: idIndex, keys: []key{{field: "_id", order: 1}}}},
+	}
+
+	for i, model := range models {
+		definition, err := newIndex(model)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index %d: %w", i, err)
+		}
+
+		if partial(model) || wildcard(definition) {
+			continue
+		}
+
+		if len(definition.keys) == 1 && definition.keys[0].field == "_id" && !definition.keys[0].hashed {
+			continue
+		}
+
+		checker.indexes = append(checker.indexes, definition)
+	}
+
+	return checker, nil
+}
+
+// Checker reports whether the indexes of a collection support queries.
+// It works purely on the index definitions and does not consider collations or multikey indexes.
+// A checker is safe for concurrent use.
+type Checker struct {
+	indexes []index
+}
+
+// Check reports the best index for given filter and sort.
+func (c *Checker) Check(filter, sort bson.D) Report {
+	return c.check(newConjunction(filter), sort)
+}
+
+// Validate rejects queries whose filter or sort is not supported by an index with an `UnindexedQueryError`.
+func (c *Checker) Validate(filter, sort bson.D) error {
+	conj := newConjunction(filter)
+
+	report := c.check(conj, sort)
+	if report.Usable() {
+		return nil
+	}
+
+	err := UnindexedQueryError{}
+
+	if !report.Filter {
+		err.filter = conj.allFields()
+	}
+
+	if !report.Sort {
+		for _, element := range sort {
+			err.sort = append(err.sort, element.Key)
+		}
+	}
+
+	return err
+}
+
+// check reports the best index for given conjunction and sort.
+func (c *Checker) check(conj *conjunction, sort bson.D) Report {
+	if conj.text {
+		return c.checkText(sort)
+	}
+
+	best := Report{Filter: conj.empty(), Sort: len(sort) == 0}
+
+	for _, definition := range c.indexes {
+		report := evaluate(definition, conj, sort)
+		if better(report, best) {
+			best = report
+		}
+	}
+
+	if best.Filter {
+		return best
+	}
+
+	for _, branches := range conj.alternatives {
+		report := Report{Filter: true, Sort: true}
+
+		for _, branch := range branches {
+			branchReport := c.check(branch, sort)
+			report.Filter = report.Filter && branchReport.Filter
+			report.Sort = report.Sort && branchReport.Sort
+			report.Branches = append(report.Branches, branchReport)
+		}
+
+		if report.Filter {
+			return report
+		}
+	}
+
+	return best
+}
+
+// checkText reports the text index that a text search must use.
+func (c *Checker) checkText(sort bson.D) Report {
+	for _, definition := range c.indexes {
+		if definition.text {
+			return Report{Index: definition.name, Filter: true, Sort: textSortable(sort), Keys: len(definition.keys)}
+		}
+	}
+
+	return Report{Sort: textSortable(sort)}
+}
+
+// textSortable reports whether the sort of a text search only sorts by the text score.
+func textSortable(sort bson.D) bool {
+	for _, element := range sort {
+		if orderOf(element.Value) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// evaluate reports the usage of given index by a query.
+// Following the equality-sort-range rule, leading keys with equality predicates bound the filter and
+// can be skipped by the sort, a key with another predicate ends the prefix and prevents the sort by later keys.
+func evaluate(definition index, conj *conjunction, sort bson.D) Report {
+	report := Report{Index: definition.name, Filter: conj.empty()}
+
+	for _, indexKey := range definition.keys {
+		kind, exists := conj.predicates[indexKey.field]
+		if !exists || kind == residual || (indexKey.order == 0 && !indexKey.hashed) {
+			break
+		}
+
+		if kind == bounded && indexKey.hashed {
+			break
+		}
+
+		report.Keys++
+
+		if kind != equality {
+			break
+		}
+	}
+
+	report.Filter = report.Filter || report.Keys > 0
+	report.Sort = sortable(definition, conj, sort)
+
+	return report
+}
+
+// sortable reports whether given index returns the documents in the order of given sort.
+func sortable(definition index, conj *conjunction, sort bson.D) bool {
+	keys := bson.D{}
+
+	for _, element := range sort {
+		if conj.predicates[element.Key] != equality {
+			keys = append(keys, element)
+		}
+	}
+
+	if len(keys) == 0 {
+		return true
+	}
+
+	if definition.sparse {
+		return false
+	}
+
+	position, multiplier := 0, 0
+
+	for _, element := range keys {
+		for position < len(definition.keys) &&
+			definition.keys[position].field != element.Key &&
+			conj.predicates[definition.keys[position].field] == equality {
+			position++
+		}
+
+		if position >= len(definition.keys) {
+			return false
+		}
+
+		indexKey := definition.keys[position]
+		order := orderOf(element.Value)
+
+		if indexKey.field != element.Key || indexKey.order == 0 || indexKey.hashed || order == 0 {
+			return false
+		}
+
+		if multiplier == 0 {
+			multiplier = order * indexKey.order
+		} else if multiplier != order*indexKey.order {
+			return false
+		}
+
+		position++
+	}
+
+	return true
+}
+
+// better reports whether a report is better than another one.
+func better(report, other Report) bool {
+	if report.Usable() != other.Usable() {
+		return report.Usable()
+	}
+
+	if report.Filter != other.Filter {
+		return report.Filter
+	}
+
+	if report.Sort != other.Sort {
+		return report.Sort
+	}
+
+	return report.Keys > other.Keys
+}
+
+// allFields returns the fields of the conjunction and its alternatives.
+func (c *conjunction) allFields() []string {
+	fields := append([]string{}, c.fields...)
+
+	for _, branches := range c.alternatives {
+		for _, branch := range branches {
+			fields = append(fields, branch.allFields()...)
+		}
+	}
+
+	return fields
+}
@@ -0,0 +1,166 @@
+//nolint:funlen
+package index
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/StevenCyb/go-mongo-tools/mongo/rsql"
+	"github.com/StevenCyb/go-mongo-tools/mongo/sort"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func newTestChecker(t *testing.T) *Checker {
+	t.Helper()
+
+	checker, err := NewChecker(
+		mongo.IndexModel{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}}},
+		mongo.IndexModel{Keys: bson.D{{Key: "name", Value: 1}}, Options: options.Index().SetName("by_name")},
+		mongo.IndexModel{Keys: bson.M{"email": "hashed"}},
+		mongo.IndexModel{Keys: bson.D{{Key: "title", Value: "text"}}},
+		mongo.IndexModel{Keys: bson.D{{Key: "nickname", Value: 1}}, Options: options.Index().SetSparse(true)},
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "archived", Value: 1}},
+			Options: options.Index().SetPartialFilterExpression(bson.D{{Key: "archived", Value: true}}),
+		},
+	)
+	require.NoError(t, err)
+
+	return checker
+}
+
+func check(t *testing.T, checker *Checker, filter, sortExpression string) Report {
+	t.Helper()
+
+	filterDocument, err := rsql.NewParser(nil).Parse(filter)
+	require.NoError(t, err)
+
+	sortDocument, err := sort.NewParser(nil).Parse(sortExpression)
+	require.NoError(t, err)
+
+	return checker.Check(filterDocument, sortDocument)
+}
+
+func TestChecker(t *testing.T) {
+	t.Parallel()
+
+	checker := newTestChecker(t)
+
+	t.Run("WithEmptyQuery_Success", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, Report{Filter: true, Sort: true}, check(t, checker, "", ""))
+	})
+
+	t.Run("WithEqualitySort_Success", func(t *testing.T) {
+		t.Parallel()
+
+		expected := Report{Index: "status_1_created_at_-1", Filter: true, Sort: true, Keys: 1}
+		require.Equal(t, expected, check(t, checker, `status=="open"`, "created_at=desc"))
+		require.Equal(t, expected, check(t, checker, `status=="open"`, "status=asc,created_at=asc"))
+		require.Equal(t, expected, check(t, checker, `status=in=("open","closed")`, "created_at=desc"))
+		require.Equal(t, Report{Index: "status_1_created_at_-1", Filter: true, Sort: true, Keys: 2},
+			check(t, checker, `status=="open";created_at=gt=5`, "created_at=desc"))
+	})
+
+	t.Run("WithSortOnly_Success", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, Report{Index: "by_name", Filter: true, Sort: true}, check(t, checker, "", "name=desc"))
+		require.Equal(t, Report{Index: "status_1_created_at_-1", Filter: true, Sort: true},
+			check(t, checker, "", "status=desc,created_at=asc"))
+	})
+
+	t.Run("WithRangeBeforeSort_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		report := check(t, checker, `status=gt=1`, "created_at=desc")
+		require.Equal(t, Report{Index: "status_1_created_at_-1", Filter: true, Sort: false, Keys: 1}, report)
+		require.False(t, report.Usable())
+	})
+
+	t.Run("WithMixedDirections_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		require.False(t, check(t, checker, "", "status=asc,created_at=asc").Sort)
+	})
+
+	t.Run("WithBoundedPredicates_Success", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, Report{Index: "by_name", Filter: true, Sort: true, Keys: 1},
+			check(t, checker, `name=sw="Jo";age=gt=18`, ""))
+		require.Equal(t, Report{Index: "_id_", Filter: true, Sort: true, Keys: 1},
+			check(t, checker, `_id!="a"`, ""))
+	})
+
+	t.Run("WithHashedIndex_Success", func(t *testing.T) {
+		t.Parallel()
+
+		require.True(t, check(t, checker, `email=="a@b.c"`, "").Usable())
+		require.False(t, check(t, checker, `email=gt=1`, "").Filter)
+		require.False(t, check(t, checker, "", "email=asc").Sort)
+	})
+
+	t.Run("WithSpecialIndexes_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		require.False(t, check(t, checker, `archived==true`, "").Filter)
+		require.False(t, check(t, checker, "", "nickname=asc").Sort)
+		require.False(t, check(t, checker, `title=="a"`, "").Filter)
+	})
+
+	t.Run("WithOr_Success", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, Report{Filter: true, Sort: true, Branches: []Report{
+			{Index: "status_1_created_at_-1", Filter: true, Sort: true, Keys: 1},
+			{Index: "by_name", Filter: true, Sort: true, Keys: 1},
+		}}, check(t, checker, `status=="open",name=="a"`, ""))
+
+		require.Equal(t, Report{Index: "status_1_created_at_-1", Filter: true, Sort: true, Keys: 1},
+			check(t, checker, `status=="open";(age==1,age==2)`, ""))
+
+		require.False(t, check(t, checker, `status=="open",age==1`, "").Filter)
+	})
+
+	t.Run("WithTextSearch_Success", func(t *testing.T) {
+		t.Parallel()
+
+		text := bson.D{{Key: "$text", Value: bson.D{{Key: "$search", Value: "coffee"}}}}
+		score := bson.D{{Key: "score", Value: bson.D{{Key: "$meta", Value: "textScore"}}}}
+
+		require.Equal(t, Report{Index: "title_text", Filter: true, Sort: true, Keys: 1}, checker.Check(text, score))
+		require.False(t, checker.Check(text, bson.D{{Key: "name", Value: 1}}).Sort)
+	})
+
+	t.Run("WithInvalidKeys_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewChecker(mongo.IndexModel{Keys: bson.M{"a": 1, "b": 1}})
+		require.True(t, errors.Is(err, ErrInvalidKeys))
+
+		_, err = NewChecker(mongo.IndexModel{Keys: "a"})
+		require.True(t, errors.Is(err, ErrInvalidKeys))
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	checker := newTestChecker(t)
+
+	require.NoError(t, checker.Validate(bson.D{{Key: "status", Value: "open"}}, bson.D{{Key: "created_at", Value: -1}}))
+
+	err := checker.Validate(
+		bson.D{{Key: "age", Value: 1}, {Key: "$or", Value: bson.A{bson.D{{Key: "size", Value: 2}}}}},
+		bson.D{{Key: "created_at", Value: -1}},
+	)
+	require.Equal(t, UnindexedQueryError{filter: []string{"age", "size"}, sort: []string{"created_at"}}, err)
+
+	err = checker.Validate(bson.D{{Key: "status", Value: bson.D{{Key: "$gt", Value: "a"}}}}, bson.D{{Key: "created_at", Value: -1}})
+	require.Equal(t, UnindexedQueryError{sort: []string{"created_at"}}, err)
+}
@@ -0,0 +1,66 @@
+package index
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+)
+
+// UnindexedQueryError indicate that no index supports the filter or the sort of a query.
+type UnindexedQueryError struct {
+	filter []string
+	sort   []string
+}
+
+func (u UnindexedQueryError) Error() string {
+	reasons := []string{}
+
+	if len(u.filter) > 0 {
+		reasons = append(reasons, fmt.Sprintf("the filter on '%s'", strings.Join(u.filter, "', '")))
+	}
+
+	if len(u.sort) > 0 {
+		reasons = append(reasons, fmt.Sprintf("the sort by '%s'", strings.Join(u.sort, "', '")))
+	}
+
+	return fmt.Sprintf("no index supports %s", strings.Join(reasons, " and "))
+}
+
+// Filter returns the fields of the filter if no index supports it.
+func (u UnindexedQueryError) Filter() []string {
+	return u.filter
+}
+
+// Sort returns the fields of the sort if no index supports it.
+func (u UnindexedQueryError) Sort() []string {
+	return u.sort
+}
+
+// Code returns the error code.
+func (u UnindexedQueryError) Code() string {
+	return errs.CodeUnindexedQuery
+}
+
+// Path returns the first unsupported field.
+func (u UnindexedQueryError) Path() string {
+	if len(u.filter) > 0 {
+		return u.filter[0]
+	}
+
+	if len(u.sort) > 0 {
+		return u.sort[0]
+	}
+
+	return ""
+}
+
+// Details returns the parameters of the error.
+func (u UnindexedQueryError) Details() map[string]interface{} {
+	return map[string]interface{}{"filter": u.filter, "sort": u.sort}
+}
+
+// Is reports whether the error belongs to given category.
+func (u UnindexedQueryError) Is(target error) bool {
+	return target == errs.ErrConstraint
+}
@@ -0,0 +1,31 @@
+package index
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnindexedQueryError(t *testing.T) {
+	t.Parallel()
+
+	err := UnindexedQueryError{filter: []string{"age", "size"}, sort: []string{"created_at"}}
+	require.Equal(t, "no index supports the filter on 'age', 'size' and the sort by 'created_at'", err.Error())
+	require.Equal(t, "age", err.Path())
+	require.Equal(t, []string{"created_at"}, err.Sort())
+	require.Equal(t, errs.CodeUnindexedQuery, err.Code())
+	require.True(t, errors.Is(err, errs.ErrConstraint))
+
+	err = UnindexedQueryError{sort: []string{"created_at"}}
+	require.Equal(t, "no index supports the sort by 'created_at'", err.Error())
+	require.Equal(t, "created_at", err.Path())
+}
+
+func TestInvalidKeysError(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, errs.CodeInvalidConfiguration, ErrInvalidKeys.Code())
+	require.True(t, errors.Is(ErrInvalidKeys, errs.ErrType))
+}
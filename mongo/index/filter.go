@@ -0,0 +1,182 @@
+package index
+
+import (
+	"regexp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// predicate is the way a filter restricts a field.
+type predicate int
+
+const (
+	// residual predicates can not be bounded by an index.
+	residual predicate = iota
+	// bounded predicates like ranges, `$ne` or regular expressions scan a part of an index.
+	bounded
+	// equality predicates like values or `$in` match single points of an index.
+	equality
+)
+
+// operators are the predicates of the query operators that can be bounded by an index.
+//
+//nolint:gochecknoglobals
+var operators = map[string]predicate{
+	"$eq":     equality,
+	"$in":     equality,
+	"$gt":     bounded,
+	"$gte":    bounded,
+	"$lt":     bounded,
+	"$lte":    bounded,
+	"$ne":     bounded,
+	"$nin":    bounded,
+	"$regex":  bounded,
+	"$exists": bounded,
+	"$type":   bounded,
+}
+
+// conjunction is a list of predicates that must all hold.
+type conjunction struct {
+	predicates map[string]predicate
+	// fields are the fields of the predicates in order of appearance.
+	fields []string
+	// alternatives are the branches of each `$or`.
+	alternatives [][]*conjunction
+	text         bool
+}
+
+// newConjunction creates the conjunction of given filter.
+func newConjunction(filter bson.D) *conjunction {
+	conj := &conjunction{predicates: map[string]predicate{}}
+	conj.add(filter)
+
+	return conj
+}
+
+// add adds the predicates of given filter.
+func (c *conjunction) add(filter bson.D) {
+	for _, element := range filter {
+		switch element.Key {
+		case "$and":
+			for _, branch := range documents(element.Value) {
+				c.add(branch)
+			}
+		case "$or":
+			branches := []*conjunction{}
+			for _, branch := range documents(element.Value) {
+				branches = append(branches, newConjunction(branch))
+			}
+
+			c.alternatives = append(c.alternatives, branches)
+		case "$text":
+			c.text = true
+		default:
+			if strings.HasPrefix(element.Key, "$") {
+				continue
+			}
+
+			c.restrict(element.Key, predicateOf(element.Value))
+		}
+	}
+}
+
+// restrict adds a predicate of a field, equality wins over other predicates of the same field.
+func (c *conjunction) restrict(field string, kind predicate) {
+	previous, exists := c.predicates[field]
+	if !exists {
+		c.fields = append(c.fields, field)
+	}
+
+	if !exists || kind > previous {
+		c.predicates[field] = kind
+	}
+}
+
+// empty reports whether the conjunction does not restrict the documents.
+func (c *conjunction) empty() bool {
+	return len(c.predicates) == 0 && len(c.alternatives) == 0 && !c.text
+}
+
+// predicateOf returns the predicate of the value of a field.
+func predicateOf(value interface{}) predicate {
+	switch value := value.(type) {
+	case bson.D:
+		if len(value) == 0 || !strings.HasPrefix(value[0].Key, "$") {
+			return equality
+		}
+
+		kind := equality
+		for _, element := range value {
+			kind = weakest(kind, operators[element.Key])
+		}
+
+		return kind
+	case bson.M:
+		kind := equality
+		for operator := range value {
+			if !strings.HasPrefix(operator, "$") {
+				return equality
+			}
+
+			kind = weakest(kind, operators[operator])
+		}
+
+		return kind
+	case bson.E:
+		return operators[value.Key]
+	case regexp.Regexp, *regexp.Regexp, primitive.Regex:
+		return bounded
+	}
+
+	return equality
+}
+
+// weakest returns the predicate that is bounded less by an index.
+func weakest(a, b predicate) predicate {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+// documents returns the documents of an `$and` or `$or`.
+func documents(value interface{}) []bson.D {
+	var result []bson.D
+
+	switch value := value.(type) {
+	case []bson.D:
+		return value
+	case bson.A:
+		for _, item := range value {
+			result = append(result, document(item))
+		}
+	case []interface{}:
+		for _, item := range value {
+			result = append(result, document(item))
+		}
+	}
+
+	return result
+}
+
+// document returns a filter as `bson.D`.
+func document(value interface{}) bson.D {
+	switch value := value.(type) {
+	case bson.D:
+		return value
+	case bson.M:
+		result := bson.D{}
+		for key, item := range value {
+			result = append(result, bson.E{Key: key, Value: item})
+		}
+
+		return result
+	case bson.E:
+		return bson.D{value}
+	}
+
+	return bson.D{}
+}
@@ -0,0 +1,122 @@
+package index
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// idIndex is the name of the index that every collection has on `_id`.
+const idIndex = "_id_"
+
+var ErrInvalidKeys = errs.NewErrSentinel(errs.ErrType, errs.CodeInvalidConfiguration,
+	"index keys must be a bson.D or a bson.M with a single key")
+
+// key of an index.
+type key struct {
+	field string
+	// order is `1` or `-1`, zero for special keys like `text` or `2dsphere`.
+	order  int
+	hashed bool
+}
+
+// index is the definition of an index.
+type index struct {
+	name   string
+	keys   []key
+	sparse bool
+	text   bool
+}
+
+// newIndex creates an index from given model.
+func newIndex(model mongo.IndexModel) (index, error) {
+	var elements bson.D
+
+	switch keys := model.Keys.(type) {
+	case bson.D:
+		elements = keys
+	case bson.M:
+		if len(keys) != 1 {
+			return index{}, ErrInvalidKeys
+		}
+
+		for field, value := range keys {
+			elements = bson.D{{Key: field, Value: value}}
+		}
+	default:
+		return index{}, ErrInvalidKeys
+	}
+
+	if len(elements) == 0 {
+		return index{}, ErrInvalidKeys
+	}
+
+	result := index{}
+	names := make([]string, 0, len(elements))
+
+	for _, element := range elements {
+		indexKey := key{field: element.Key, order: orderOf(element.Value)}
+		indexKey.hashed = element.Value == "hashed"
+		result.text = result.text || element.Value == "text"
+		result.keys = append(result.keys, indexKey)
+		names = append(names, fmt.Sprintf("%s_%v", element.Key, element.Value))
+	}
+
+	result.name = strings.Join(names, "_")
+
+	if model.Options != nil {
+		if model.Options.Name != nil {
+			result.name = *model.Options.Name
+		}
+
+		result.sparse = model.Options.Sparse != nil && *model.Options.Sparse
+	}
+
+	return result, nil
+}
+
+// partial reports whether given model only indexes a part of the documents.
+func partial(model mongo.IndexModel) bool {
+	return model.Options != nil && model.Options.PartialFilterExpression != nil
+}
+
+// wildcard reports whether given index has a wildcard key.
+func wildcard(index index) bool {
+	for _, indexKey := range index.keys {
+		if strings.HasSuffix(indexKey.field, "$**") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// orderOf returns the sign of a numeric index or sort value, zero for other values.
+func orderOf(value interface{}) int {
+	var number float64
+
+	switch value := value.(type) {
+	case int:
+		number = float64(value)
+	case int32:
+		number = float64(value)
+	case int64:
+		number = float64(value)
+	case float32:
+		number = float64(value)
+	case float64:
+		number = value
+	}
+
+	switch {
+	case number > 0:
+		return 1
+	case number < 0:
+		return -1
+	}
+
+	return 0
+}
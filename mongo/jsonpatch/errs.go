@@ -0,0 +1,72 @@
+package jsonpatch
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+	"github.com/StevenCyb/go-mongo-tools/mongo/jsonpatch/operation"
+)
+
+// OperationError indicate that an operation of a patch is invalid.
+type OperationError struct {
+	err       error
+	operation operation.Spec
+	index     int
+}
+
+func (o OperationError) Error() string {
+	return fmt.Sprintf("operation %d '%+v' invalid: %s", o.index, o.operation, o.err)
+}
+
+// Unwrap returns the reason why the operation is invalid.
+func (o OperationError) Unwrap() error {
+	return o.err
+}
+
+// Index returns the index of the invalid operation within the patch.
+func (o OperationError) Index() int {
+	return o.index
+}
+
+// Operation returns the invalid operation.
+func (o OperationError) Operation() operation.Spec {
+	return o.operation
+}
+
+// Code returns the code of the reason or a generic code if unknown.
+func (o OperationError) Code() string {
+	var reason errs.Error
+	if errors.As(o.err, &reason) {
+		return reason.Code()
+	}
+
+	return errs.CodeInvalidOperation
+}
+
+// Path returns the path reported by the reason or else the path of the operation.
+func (o OperationError) Path() string {
+	var reason errs.Error
+	if errors.As(o.err, &reason) && reason.Path() != "" && !errors.Is(reason, errs.ErrPolicy) {
+		return reason.Path()
+	}
+
+	return string(o.operation.Path)
+}
+
+// Details returns the parameters of the reason extended by the index of the operation.
+func (o OperationError) Details() map[string]interface{} {
+	details := map[string]interface{}{}
+
+	var reason errs.Error
+	if errors.As(o.err, &reason) {
+		for key, value := range reason.Details() {
+			details[key] = value
+		}
+	}
+
+	details["index"] = o.index
+	details["operation"] = string(o.operation.Operation)
+
+	return details
+}
@@ -0,0 +1,56 @@
+package jsonpatch
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+	"github.com/StevenCyb/go-mongo-tools/mongo/jsonpatch/operation"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationError(t *testing.T) {
+	t.Parallel()
+
+	reason := errs.NewErrPolicyViolation("test")
+	spec := operation.Spec{Operation: operation.RemoveOperation, Path: "a"}
+	err := OperationError{index: 2, operation: spec, err: reason}
+
+	require.Equal(t, "operation 2 '{From: Path:a Value:<nil> Operation:remove}' invalid: "+
+		"Policy violation, policy disallow \"test\"", err.Error())
+	require.Equal(t, 2, err.Index())
+	require.Equal(t, spec, err.Operation())
+
+	var policyViolationErr errs.PolicyViolationError
+	require.ErrorAs(t, err, &policyViolationErr)
+	require.Equal(t, reason, policyViolationErr)
+}
+
+func TestOperationErrorCode(t *testing.T) {
+	t.Parallel()
+
+	spec := operation.Spec{Operation: operation.RemoveOperation, Path: "a"}
+
+	err := OperationError{index: 1, operation: spec, err: errs.NewErrPolicyViolation("test")}
+	require.Equal(t, errs.CodePolicyViolation, err.Code())
+	require.Equal(t, "a", err.Path())
+	require.Equal(t, 1, err.Details()["index"])
+	require.Equal(t, "test", err.Details()["actual"])
+	require.ErrorIs(t, err, errs.ErrPolicy)
+
+	err = OperationError{index: 0, operation: spec, err: operation.ErrUnknownOperation}
+	require.Equal(t, errs.CodeUnknownOperation, err.Code())
+	require.Equal(t, "a", err.Path())
+	require.ErrorIs(t, err, errs.ErrUnknown)
+
+	err = OperationError{index: 0, operation: spec, err: errors.New("test")} //nolint:goerr113
+	require.Equal(t, errs.CodeInvalidOperation, err.Code())
+	require.Equal(t, "a", err.Path())
+}
+
+func TestNoOperationToPerformError(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, errs.CodeNoOperation, ErrNoOperationToPerform.Code())
+	require.ErrorIs(t, ErrNoOperationToPerform, errs.ErrSyntax)
+}
@@ -1,13 +1,14 @@
 package forcecast
 
 import (
-	"errors"
 	"reflect"
 
+	"github.com/StevenCyb/go-mongo-tools/errs"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-var ErrInvalidTypeForObjectID = errors.New("ObjectID must be string (24 characters) or array (12 bytes)")
+var ErrInvalidTypeForObjectID = errs.NewErrSentinel(errs.ErrType, errs.CodeTypeMismatch,
+	"ObjectID must be string (24 characters) or array (12 bytes)")
 
 const (
 	objectIDStringLen = 24
@@ -1,16 +1,16 @@
 package forcecast
 
 import (
-	"errors"
 	"fmt"
 	"reflect"
 
+	"github.com/StevenCyb/go-mongo-tools/errs"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 var (
-	ErrNoArrayType           = errors.New("value is not valid array")
-	ErrImpossibleCastToArray = errors.New("impossible to cast input to array")
+	ErrNoArrayType           = errs.NewErrSentinel(errs.ErrType, errs.CodeTypeMismatch, "value is not valid array")
+	ErrImpossibleCastToArray = errs.NewErrSentinel(errs.ErrType, errs.CodeTypeMismatch, "impossible to cast input to array")
 )
 
 type ObjectIDArrayCast struct{}
@@ -3,6 +3,7 @@ package forcecast
 import (
 	"testing"
 
+	"github.com/StevenCyb/go-mongo-tools/errs"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -40,3 +41,12 @@ func TestObjectID(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, objectID, castedObjectID)
 }
+
+func TestCastErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, err := range []*errs.SentinelError{ErrInvalidTypeForObjectID, ErrNoArrayType, ErrImpossibleCastToArray} {
+		require.Equal(t, errs.CodeTypeMismatch, err.Code())
+		require.ErrorIs(t, err, errs.ErrType)
+	}
+}
@@ -1,12 +1,13 @@
 package operation
 
 import (
-	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
 )
 
-var ErrUnknownOperation = errors.New("unknown operation")
+var ErrUnknownOperation = errs.NewErrSentinel(errs.ErrUnknown, errs.CodeUnknownOperation, "unknown operation")
 
 // Operation represents an JSON patch operation.
 type Operation string
@@ -4,6 +4,7 @@ package operation
 import (
 	"testing"
 
+	"github.com/StevenCyb/go-mongo-tools/errs"
 	"github.com/stretchr/testify/require"
 )
 
@@ -176,3 +177,10 @@ func TestCopyOperationOperationValidation(t *testing.T) {
 		require.False(t, Spec{Operation: operation, Path: pathB, From: invalidPath}.Valid())
 	})
 }
+
+func TestUnknownOperationError(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, errs.CodeUnknownOperation, ErrUnknownOperation.Code())
+	require.ErrorIs(t, ErrUnknownOperation, errs.ErrUnknown)
+}
@@ -1,7 +1,7 @@
 package jsonpatch
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -13,7 +13,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 )
 
-var ErrNoOperationToPerform = errors.New("no operation to perform")
+var ErrNoOperationToPerform = errs.NewErrSentinel(errs.ErrSyntax, errs.CodeNoOperation, "no operation to perform")
 
 // NewParser creates a new parser that uses optional policies.
 func NewParser(policies ...Policy) *Parser {
@@ -46,27 +46,36 @@ type Parser struct {
 
 // Parse given operation spec to generate mongo queries if not violating policies.
 func (p Parser) Parse(operationSpecs ...operation.Spec) (bson.A, error) {
+	return p.ParseContext(context.Background(), operationSpecs...)
+}
+
+// ParseContext works like Parse but passes the context to context-aware policies.
+func (p Parser) ParseContext(ctx context.Context, operationSpecs ...operation.Spec) (bson.A, error) {
 	if len(operationSpecs) == 0 {
 		return nil, ErrNoOperationToPerform
 	}
 
 	for _, policy := range p.policies {
-		for _, operationSpec := range operationSpecs {
+		for index, operationSpec := range operationSpecs {
 			if !operationSpec.Valid() {
-				return nil, errs.NewErrUnexpectedInput(operationSpec)
+				return nil, OperationError{
+					index: index, operation: operationSpec, err: errs.NewErrUnexpectedInput(operationSpec),
+				}
 			}
 
-			if !policy.Test(operationSpec) {
-				return nil, errs.NewErrPolicyViolation(policy.GetDetails())
+			if !testPolicy(ctx, policy, operationSpec) {
+				return nil, OperationError{
+					index: index, operation: operationSpec, err: errs.NewErrPolicyViolation(policy.GetDetails()),
+				}
 			}
 		}
 	}
 
 	if p.validator != nil {
-		for _, operationSpec := range operationSpecs {
+		for index, operationSpec := range operationSpecs {
 			err := p.validator.Validate(operationSpec)
 			if err != nil {
-				return nil, fmt.Errorf("operation '%+v' invalid: %w", operationSpec, err)
+				return nil, OperationError{index: index, operation: operationSpec, err: err}
 			}
 		}
 	}
@@ -74,6 +83,60 @@ func (p Parser) Parse(operationSpecs ...operation.Spec) (bson.A, error) {
 	return p.generateMongoQuery(operationSpecs...)
 }
 
+// ParseAll works like Parse but validates every operation against every policy and rule.
+// All violations are returned as `errs.Chain` error of `OperationError`.
+func (p Parser) ParseAll(operationSpecs ...operation.Spec) (bson.A, error) {
+	return p.ParseAllContext(context.Background(), operationSpecs...)
+}
+
+// ParseAllContext works like ParseAll but passes the context to context-aware policies.
+func (p Parser) ParseAllContext(ctx context.Context, operationSpecs ...operation.Spec) (bson.A, error) {
+	if len(operationSpecs) == 0 {
+		return nil, ErrNoOperationToPerform
+	}
+
+	errChain := errs.Chain{}
+
+	for index, operationSpec := range operationSpecs {
+		if !operationSpec.Valid() {
+			errChain.AddIf(OperationError{
+				index: index, operation: operationSpec, err: errs.NewErrUnexpectedInput(operationSpec),
+			})
+
+			continue
+		}
+
+		for _, policy := range p.policies {
+			if !testPolicy(ctx, policy, operationSpec) {
+				errChain.AddIf(OperationError{
+					index: index, operation: operationSpec, err: errs.NewErrPolicyViolation(policy.GetDetails()),
+				})
+			}
+		}
+
+		if p.validator != nil {
+			for _, err := range errs.Errors(p.validator.ValidateAll(operationSpec)) {
+				errChain.AddIf(OperationError{index: index, operation: operationSpec, err: err})
+			}
+		}
+	}
+
+	if err := errChain.GetError(); err != nil {
+		return nil, err
+	}
+
+	return p.generateMongoQuery(operationSpecs...)
+}
+
+// testPolicy tests an operation with the context if the policy is context-aware.
+func testPolicy(ctx context.Context, policy Policy, operationSpec operation.Spec) bool {
+	if contextPolicy, ok := policy.(ContextPolicy); ok {
+		return contextPolicy.TestContext(ctx, operationSpec)
+	}
+
+	return policy.Test(operationSpec)
+}
+
 // generateMongoQuery generates the mongo query out of operation spec.
 //
 //nolint:funlen
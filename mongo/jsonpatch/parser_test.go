@@ -8,6 +8,8 @@ import (
 
 	"github.com/StevenCyb/go-mongo-tools/errs"
 	"github.com/StevenCyb/go-mongo-tools/mongo/jsonpatch/operation"
+	"github.com/StevenCyb/go-mongo-tools/mongo/jsonpatch/rule"
+	"github.com/StevenCyb/go-mongo-tools/mongo/jsonpatch/validator"
 	testutil "github.com/StevenCyb/go-mongo-tools/mongo/test_util"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson"
@@ -197,7 +199,9 @@ func TestInvalidOperation(t *testing.T) {
 		Parser{
 			policies: []Policy{DisallowPathPolicy{Details: name, Path: path}},
 		},
-		errs.NewErrUnexpectedInput(operation.Spec{}),
+		OperationError{
+			index: 0, operation: operation.Spec{}, err: errs.NewErrUnexpectedInput(operation.Spec{}),
+		},
 		operation.Spec{},
 	)
 }
@@ -212,11 +216,71 @@ func TestPolicyViolation(t *testing.T) {
 		Parser{
 			policies: []Policy{DisallowPathPolicy{Details: name, Path: path}},
 		},
-		errs.NewErrPolicyViolation(name),
+		OperationError{
+			index:     1,
+			operation: operation.Spec{Operation: operation.RemoveOperation, Path: path},
+			err:       errs.NewErrPolicyViolation(name),
+		},
+		operation.Spec{Operation: operation.RemoveOperation, Path: "user.b"},
 		operation.Spec{Operation: operation.RemoveOperation, Path: path},
 	)
 }
 
+func TestParseAll(t *testing.T) {
+	t.Parallel()
+
+	referenceValidator, err := validator.NewValidator(reflect.TypeOf(DummyDoc{}))
+	require.NoError(t, err)
+
+	parser := Parser{
+		policies:  []Policy{DisallowPathPolicy{Details: "MustFailPolicy", Path: "a"}},
+		validator: referenceValidator,
+	}
+
+	t.Run("ValidOperations_Success", func(t *testing.T) {
+		t.Parallel()
+
+		query, err := parser.ParseAll(operation.Spec{Operation: operation.ReplaceOperation, Path: "b", Value: "new"})
+		require.NoError(t, err)
+		require.NotNil(t, query)
+	})
+
+	t.Run("AllViolations_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		query, err := parser.ParseAll(
+			operation.Spec{Operation: operation.ReplaceOperation, Path: "a", Value: 1},
+			operation.Spec{Operation: operation.ReplaceOperation, Path: "b", Value: "new"},
+			operation.Spec{},
+			operation.Spec{Operation: operation.ReplaceOperation, Path: "_id", Value: "new"},
+		)
+		require.Error(t, err)
+		require.Nil(t, query)
+
+		violations := errs.Errors(err)
+		require.Len(t, violations, 4)
+
+		expectedIndexes := []int{0, 0, 2, 3}
+		for i, violation := range violations {
+			operationErr, ok := violation.(OperationError)
+			require.True(t, ok)
+			require.Equal(t, expectedIndexes[i], operationErr.Index())
+		}
+
+		require.ErrorIs(t, violations[0], errs.ErrPolicy)
+		require.ErrorIs(t, violations[1], errs.ErrType)
+		require.ErrorIs(t, violations[2], errs.ErrSyntax)
+		require.ErrorIs(t, violations[3], rule.ErrOperationsNotAllowed)
+	})
+
+	t.Run("NoOperation_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := parser.ParseAll()
+		require.ErrorIs(t, err, ErrNoOperationToPerform)
+	})
+}
+
 func TestSmartParsing(t *testing.T) {
 	t.Parallel()
 
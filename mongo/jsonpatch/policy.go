@@ -1,11 +1,13 @@
 package jsonpatch
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"regexp"
 
 	"github.com/StevenCyb/go-mongo-tools/mongo/jsonpatch/operation"
+	"github.com/StevenCyb/go-mongo-tools/tokenizer"
 )
 
 // Policy specifies the interface for an policy.
@@ -14,6 +16,12 @@ type Policy interface {
 	Test(operationSpec operation.Spec) bool
 }
 
+// ContextPolicy specifies the interface for an policy that depends on the context.
+type ContextPolicy interface {
+	Policy
+	TestContext(ctx context.Context, operationSpec operation.Spec) bool
+}
+
 // DisallowPathPolicy specifies a path that is not allowed.
 type DisallowPathPolicy struct {
 	Details string
@@ -141,3 +149,29 @@ func (d ForceOperationOnPathPolicy) Test(operationSpec operation.Spec) bool {
 
 	return d.Operation == operationSpec.Operation
 }
+
+// FieldAccessPolicy applies a field policy e.g. of the rsql and sort parser
+// to the path (and from) of an operation.
+type FieldAccessPolicy struct {
+	Policy  tokenizer.FieldPolicy
+	Details string
+}
+
+// GetDetails returns the name of this policy.
+func (f FieldAccessPolicy) GetDetails() string {
+	return f.Details
+}
+
+// Test if given operation specification is valid or not.
+func (f FieldAccessPolicy) Test(operationSpec operation.Spec) bool {
+	return f.TestContext(context.Background(), operationSpec)
+}
+
+// TestContext tests if given operation specification is valid or not within the context.
+func (f FieldAccessPolicy) TestContext(ctx context.Context, operationSpec operation.Spec) bool {
+	if !f.Policy.AllowContext(ctx, string(operationSpec.Path)) {
+		return false
+	}
+
+	return operationSpec.From == "" || f.Policy.AllowContext(ctx, string(operationSpec.From))
+}
@@ -2,12 +2,15 @@
 package jsonpatch
 
 import (
+	"context"
 	"reflect"
 	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/StevenCyb/go-mongo-tools/errs"
 	"github.com/StevenCyb/go-mongo-tools/mongo/jsonpatch/operation"
+	"github.com/StevenCyb/go-mongo-tools/tokenizer"
 	"github.com/stretchr/testify/require"
 )
 
@@ -16,11 +19,12 @@ func TestPolicyInterface(t *testing.T) {
 	// just test the assigning
 
 	var (
-		policy1 Policy = DisallowPathPolicy{}
-		policy2 Policy = DisallowOperationOnPathPolicy{}
-		policy3 Policy = ForceTypeOnPathPolicy{}
-		policy4 Policy = ForceRegexMatchPolicy{}
-		policy5 Policy = StrictPathPolicy{}
+		policy1 Policy        = DisallowPathPolicy{}
+		policy2 Policy        = DisallowOperationOnPathPolicy{}
+		policy3 Policy        = ForceTypeOnPathPolicy{}
+		policy4 Policy        = ForceRegexMatchPolicy{}
+		policy5 Policy        = StrictPathPolicy{}
+		policy6 ContextPolicy = FieldAccessPolicy{}
 	)
 
 	// so the variables are used
@@ -29,6 +33,7 @@ func TestPolicyInterface(t *testing.T) {
 	require.NotNil(t, policy3)
 	require.NotNil(t, policy4)
 	require.NotNil(t, policy5)
+	require.NotNil(t, policy6)
 }
 
 func TestDisallowPathPolicy(t *testing.T) {
@@ -147,3 +152,42 @@ func TestForceOperationOnPathPolicy(t *testing.T) {
 	require.False(t, policy.Test(operation.Spec{Path: path, Operation: operation.RemoveOperation}))
 	require.False(t, policy.Test(operation.Spec{Path: path, Operation: operation.ReplaceOperation}))
 }
+
+func TestFieldAccessPolicy(t *testing.T) {
+	t.Parallel()
+
+	type roleKey struct{}
+
+	details := "something"
+	policy := FieldAccessPolicy{
+		Details: details,
+		Policy: tokenizer.AnyOf(
+			tokenizer.NewPolicy(tokenizer.BlacklistPolicy, "user.password"),
+			tokenizer.PolicyFunc(func(ctx context.Context, _ string) bool {
+				return ctx.Value(roleKey{}) == "admin"
+			}),
+		),
+	}
+	admin := context.WithValue(context.Background(), roleKey{}, "admin")
+
+	require.Equal(t, details, policy.GetDetails())
+	require.True(t, policy.Test(operation.Spec{Path: "user.name"}))
+	require.False(t, policy.Test(operation.Spec{Path: "user.password"}))
+	require.False(t, policy.Test(operation.Spec{Path: "user.name", From: "user.password"}))
+	require.True(t, policy.TestContext(admin, operation.Spec{Path: "user.password"}))
+
+	parser := NewParser(policy)
+	spec := operation.Spec{Operation: operation.RemoveOperation, Path: "user.password"}
+
+	_, err := parser.ParseContext(admin, spec)
+	require.NoError(t, err)
+
+	_, err = parser.Parse(spec)
+	require.ErrorIs(t, err, errs.ErrPolicy)
+
+	_, err = parser.ParseAllContext(admin, spec)
+	require.NoError(t, err)
+
+	_, err = parser.ParseAll(spec)
+	require.ErrorIs(t, err, errs.ErrPolicy)
+}
@@ -1,14 +1,14 @@
 package rule
 
 import (
-	"errors"
 	"fmt"
 	"reflect"
 
+	"github.com/StevenCyb/go-mongo-tools/errs"
 	"github.com/StevenCyb/go-mongo-tools/mongo/jsonpatch/operation"
 )
 
-var ErrOperationsNotAllowed = errors.New("operations are not allowed")
+var ErrOperationsNotAllowed = errs.NewErrSentinel(errs.ErrPolicy, errs.CodeOperationNotAllowed, "operations are not allowed")
 
 // LessThenError indicate that a value is less then the reference.
 type LessThenError struct {
@@ -20,6 +20,36 @@ func (l LessThenError) Error() string {
 	return fmt.Sprintf("value is less then specified: '%f' < '%f'", l.value, l.ref)
 }
 
+// Reference returns the minimum.
+func (l LessThenError) Reference() float64 {
+	return l.ref
+}
+
+// Value returns the value that is too small.
+func (l LessThenError) Value() float64 {
+	return l.value
+}
+
+// Code returns the error code.
+func (l LessThenError) Code() string {
+	return errs.CodeValueTooSmall
+}
+
+// Path returns an empty string since the rule does not know the path.
+func (l LessThenError) Path() string {
+	return ""
+}
+
+// Details returns the parameters of the error.
+func (l LessThenError) Details() map[string]interface{} {
+	return map[string]interface{}{"reference": l.ref, "value": l.value}
+}
+
+// Is reports whether the error belongs to given category.
+func (l LessThenError) Is(target error) bool {
+	return target == errs.ErrConstraint
+}
+
 // GreaterThenError indicate that a value is greater then the reference.
 type GreaterThenError struct {
 	ref   float64
@@ -30,6 +60,36 @@ func (g GreaterThenError) Error() string {
 	return fmt.Sprintf("value is greater then specified: '%f' > '%f'", g.value, g.ref)
 }
 
+// Reference returns the maximum.
+func (g GreaterThenError) Reference() float64 {
+	return g.ref
+}
+
+// Value returns the value that is too large.
+func (g GreaterThenError) Value() float64 {
+	return g.value
+}
+
+// Code returns the error code.
+func (g GreaterThenError) Code() string {
+	return errs.CodeValueTooLarge
+}
+
+// Path returns an empty string since the rule does not know the path.
+func (g GreaterThenError) Path() string {
+	return ""
+}
+
+// Details returns the parameters of the error.
+func (g GreaterThenError) Details() map[string]interface{} {
+	return map[string]interface{}{"reference": g.ref, "value": g.value}
+}
+
+// Is reports whether the error belongs to given category.
+func (g GreaterThenError) Is(target error) bool {
+	return target == errs.ErrConstraint
+}
+
 // OperationNotAllowedError indicate that a given JSON patch operation is not allowed.
 type OperationNotAllowedError struct {
 	operation operation.Operation
@@ -39,6 +99,31 @@ func (o OperationNotAllowedError) Error() string {
 	return fmt.Sprintf("operation '%s' not allowed", o.operation)
 }
 
+// Operation returns the disallowed operation.
+func (o OperationNotAllowedError) Operation() operation.Operation {
+	return o.operation
+}
+
+// Code returns the error code.
+func (o OperationNotAllowedError) Code() string {
+	return errs.CodeOperationNotAllowed
+}
+
+// Path returns an empty string since the rule does not know the path.
+func (o OperationNotAllowedError) Path() string {
+	return ""
+}
+
+// Details returns the parameters of the error.
+func (o OperationNotAllowedError) Details() map[string]interface{} {
+	return map[string]interface{}{"operation": string(o.operation)}
+}
+
+// Is reports whether the error belongs to given category.
+func (o OperationNotAllowedError) Is(target error) bool {
+	return target == errs.ErrPolicy
+}
+
 // UnknownFieldError indicate that a field is not known.
 type UnknownFieldError struct {
 	name string
@@ -48,6 +133,26 @@ func (u UnknownFieldError) Error() string {
 	return fmt.Sprintf("unknown field '%s'", u.name)
 }
 
+// Path returns the path of the unknown field.
+func (u UnknownFieldError) Path() string {
+	return u.name
+}
+
+// Code returns the error code.
+func (u UnknownFieldError) Code() string {
+	return errs.CodeUnknownField
+}
+
+// Details returns the parameters of the error.
+func (u UnknownFieldError) Details() map[string]interface{} {
+	return map[string]interface{}{"path": u.name}
+}
+
+// Is reports whether the error belongs to given category.
+func (u UnknownFieldError) Is(target error) bool {
+	return target == errs.ErrUnknown
+}
+
 // TypeMismatchError indicate that a given type not match a reference.
 type TypeMismatchError struct {
 	name     string
@@ -64,6 +169,46 @@ func (t TypeMismatchError) Error() string {
 	return fmt.Sprintf("'%s' has invalid kind '%s', must be '%s'", t.name, t.actual.String(), t.expected.String())
 }
 
+// Path returns the path of the mismatching value.
+func (t TypeMismatchError) Path() string {
+	return t.name
+}
+
+// Expected returns the kind of the reference.
+func (t TypeMismatchError) Expected() reflect.Kind {
+	return t.expected
+}
+
+// Actual returns the kind of the given value.
+func (t TypeMismatchError) Actual() reflect.Kind {
+	return t.actual
+}
+
+// ForKey reports whether the key of a map mismatches instead of the value.
+func (t TypeMismatchError) ForKey() bool {
+	return t.forKey
+}
+
+// Code returns the error code.
+func (t TypeMismatchError) Code() string {
+	return errs.CodeTypeMismatch
+}
+
+// Details returns the parameters of the error.
+func (t TypeMismatchError) Details() map[string]interface{} {
+	return map[string]interface{}{
+		"path":     t.name,
+		"expected": t.expected.String(),
+		"actual":   t.actual.String(),
+		"forKey":   t.forKey,
+	}
+}
+
+// Is reports whether the error belongs to given category.
+func (t TypeMismatchError) Is(target error) bool {
+	return target == errs.ErrType
+}
+
 // ExpressionNotMatchError indicate that given value not match expression.
 type ExpressionNotMatchError struct {
 	expression string
@@ -73,3 +218,33 @@ type ExpressionNotMatchError struct {
 func (e ExpressionNotMatchError) Error() string {
 	return fmt.Sprintf("expression '%s' not match %s", e.expression, e.value)
 }
+
+// Expression returns the expression that was not matched.
+func (e ExpressionNotMatchError) Expression() string {
+	return e.expression
+}
+
+// Value returns the value that does not match.
+func (e ExpressionNotMatchError) Value() string {
+	return e.value
+}
+
+// Code returns the error code.
+func (e ExpressionNotMatchError) Code() string {
+	return errs.CodeExpressionMismatch
+}
+
+// Path returns an empty string since the rule does not know the path.
+func (e ExpressionNotMatchError) Path() string {
+	return ""
+}
+
+// Details returns the parameters of the error.
+func (e ExpressionNotMatchError) Details() map[string]interface{} {
+	return map[string]interface{}{"expression": e.expression, "value": e.value}
+}
+
+// Is reports whether the error belongs to given category.
+func (e ExpressionNotMatchError) Is(target error) bool {
+	return target == errs.ErrConstraint
+}
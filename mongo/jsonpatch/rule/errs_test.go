@@ -1,9 +1,11 @@
 package rule
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
+	"github.com/StevenCyb/go-mongo-tools/errs"
 	"github.com/StevenCyb/go-mongo-tools/mongo/jsonpatch/operation"
 	"github.com/stretchr/testify/require"
 )
@@ -34,6 +36,7 @@ func TestUnknownFieldError(t *testing.T) {
 
 	require.Equal(t, "unknown field 'test'",
 		UnknownFieldError{name: "test"}.Error())
+	require.Equal(t, "test", UnknownFieldError{name: "test"}.Path())
 }
 
 func TestTypeMismatchError(t *testing.T) {
@@ -43,6 +46,7 @@ func TestTypeMismatchError(t *testing.T) {
 		TypeMismatchError{name: "test", expected: reflect.String, actual: reflect.Int}.Error())
 	require.Equal(t, "'test' key has invalid kind 'int', must be 'string'",
 		TypeMismatchError{name: "test", expected: reflect.String, actual: reflect.Int, forKey: true}.Error())
+	require.Equal(t, "test", TypeMismatchError{name: "test"}.Path())
 }
 
 func TestExpressionNotMatchError(t *testing.T) {
@@ -51,3 +55,49 @@ func TestExpressionNotMatchError(t *testing.T) {
 	require.Equal(t, `expression '^\d$' not match a`,
 		ExpressionNotMatchError{expression: `^\d$`, value: "a"}.Error())
 }
+
+func TestErrorCategories(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		err      errs.Error
+		category error
+		code     string
+		path     string
+	}{
+		{LessThenError{ref: 3, value: 2}, errs.ErrConstraint, errs.CodeValueTooSmall, ""},
+		{GreaterThenError{ref: 2, value: 3}, errs.ErrConstraint, errs.CodeValueTooLarge, ""},
+		{OperationNotAllowedError{operation: operation.AddOperation}, errs.ErrPolicy, errs.CodeOperationNotAllowed, ""},
+		{UnknownFieldError{name: "a.b"}, errs.ErrUnknown, errs.CodeUnknownField, "a.b"},
+		{TypeMismatchError{name: "a", expected: reflect.String, actual: reflect.Int}, errs.ErrType, errs.CodeTypeMismatch, "a"},
+		{ExpressionNotMatchError{expression: "^a$", value: "b"}, errs.ErrConstraint, errs.CodeExpressionMismatch, ""},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.code, testCase.err.Code())
+		require.Equal(t, testCase.path, testCase.err.Path())
+		require.ErrorIs(t, testCase.err, testCase.category)
+		require.NotErrorIs(t, testCase.err, errs.ErrSyntax)
+	}
+
+	err := TypeMismatchError{name: "a", expected: reflect.String, actual: reflect.Int, forKey: true}
+	require.Equal(t, reflect.String, err.Expected())
+	require.Equal(t, reflect.Int, err.Actual())
+	require.True(t, err.ForKey())
+	require.Equal(t, "int", err.Details()["actual"])
+}
+
+func TestSentinelErrors(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, errs.CodeOperationNotAllowed, ErrOperationsNotAllowed.Code())
+	require.True(t, errors.Is(ErrOperationsNotAllowed, errs.ErrPolicy))
+	require.Equal(t, errs.CodeValueTooLarge, ErrMaxRuleViolation.Code())
+	require.True(t, errors.Is(ErrMaxRuleViolation, errs.ErrConstraint))
+	require.Equal(t, errs.CodeTypeMismatch, ErrAddOperationTypeError.Code())
+	require.True(t, errors.Is(ErrAddOperationTypeError, errs.ErrType))
+
+	for _, err := range []*errs.SentinelError{ErrInvalidKind, ErrInvalidBool, ErrInvalidNumber} {
+		require.Equal(t, errs.CodeInvalidTag, err.Code())
+	}
+}
@@ -2,13 +2,13 @@
 package rule
 
 import (
-	"errors"
 	"reflect"
 
+	"github.com/StevenCyb/go-mongo-tools/errs"
 	"github.com/StevenCyb/go-mongo-tools/mongo/jsonpatch/operation"
 )
 
-var ErrAddOperationTypeError = errors.New("add operation only applicable to array")
+var ErrAddOperationTypeError = errs.NewErrSentinel(errs.ErrType, errs.CodeTypeMismatch, "add operation only applicable to array")
 
 // MatchingOperationToKindRule is a default rule that is applied to all fields.
 // This rules if operation is applyable to kind.
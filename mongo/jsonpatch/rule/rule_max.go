@@ -2,15 +2,15 @@
 package rule
 
 import (
-	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
 
+	"github.com/StevenCyb/go-mongo-tools/errs"
 	"github.com/StevenCyb/go-mongo-tools/mongo/jsonpatch/operation"
 )
 
-var ErrMaxRuleViolation = errors.New("value greater then specified")
+var ErrMaxRuleViolation = errs.NewErrSentinel(errs.ErrConstraint, errs.CodeValueTooLarge, "value greater then specified")
 
 // MaxRule defines the maximum size/value:
 /*
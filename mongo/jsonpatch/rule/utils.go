@@ -1,19 +1,19 @@
 package rule
 
 import (
-	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
 
+	"github.com/StevenCyb/go-mongo-tools/errs"
 	"github.com/StevenCyb/go-mongo-tools/mongo/jsonpatch/operation"
 )
 
 var (
-	ErrInvalidKind   = errors.New("is invalid kind")
-	ErrInvalidBool   = errors.New("is invalid bool")
-	ErrInvalidNumber = errors.New("is invalid number")
+	ErrInvalidKind   = errs.NewErrSentinel(errs.ErrType, errs.CodeInvalidTag, "is invalid kind")
+	ErrInvalidBool   = errs.NewErrSentinel(errs.ErrSyntax, errs.CodeInvalidTag, "is invalid bool")
+	ErrInvalidNumber = errs.NewErrSentinel(errs.ErrSyntax, errs.CodeInvalidTag, "is invalid number")
 )
 
 // getBoolIfNotEmpty parse string to bool or throw error.
@@ -1,15 +1,16 @@
 package validator
 
 import (
-	"errors"
 	"fmt"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
 )
 
 var (
-	ErrMissingPrefix     = errors.New("missing tag prefix '" + prefix + "'")
-	ErrDuplicateRuleTags = errors.New("tag name already registered")
-	ErrNilRule           = errors.New("rule is nil")
-	ErrReferenceIsNil    = errors.New("reference is nil")
+	ErrMissingPrefix     = errs.NewErrSentinel(errs.ErrSyntax, errs.CodeInvalidTag, "missing tag prefix '"+prefix+"'")
+	ErrDuplicateRuleTags = errs.NewErrSentinel(errs.ErrConstraint, errs.CodeInvalidConfiguration, "tag name already registered")
+	ErrNilRule           = errs.NewErrSentinel(errs.ErrType, errs.CodeNilReference, "rule is nil")
+	ErrReferenceIsNil    = errs.NewErrSentinel(errs.ErrType, errs.CodeNilReference, "reference is nil")
 )
 
 // InvalidTypeError indicate that a type is invalid.
@@ -21,6 +22,26 @@ func (i InvalidTypeError) Error() string {
 	return fmt.Sprintf("type at '%s' is invalid", i.path)
 }
 
+// Path returns the path of the invalid type.
+func (i InvalidTypeError) Path() string {
+	return i.path
+}
+
+// Code returns the error code.
+func (i InvalidTypeError) Code() string {
+	return errs.CodeInvalidType
+}
+
+// Details returns the parameters of the error.
+func (i InvalidTypeError) Details() map[string]interface{} {
+	return map[string]interface{}{"path": i.path}
+}
+
+// Is reports whether the error belongs to given category.
+func (i InvalidTypeError) Is(target error) bool {
+	return target == errs.ErrType
+}
+
 // UnknownRuleError indicate that requested rule is not known.
 type UnknownRuleError struct {
 	name string
@@ -30,6 +51,31 @@ func (u UnknownRuleError) Error() string {
 	return fmt.Sprintf("unknown rule '%s'", u.name)
 }
 
+// Name returns the name of the unknown rule.
+func (u UnknownRuleError) Name() string {
+	return u.name
+}
+
+// Code returns the error code.
+func (u UnknownRuleError) Code() string {
+	return errs.CodeUnknownRule
+}
+
+// Path returns an empty string since rules have no path.
+func (u UnknownRuleError) Path() string {
+	return ""
+}
+
+// Details returns the parameters of the error.
+func (u UnknownRuleError) Details() map[string]interface{} {
+	return map[string]interface{}{"name": u.name}
+}
+
+// Is reports whether the error belongs to given category.
+func (u UnknownRuleError) Is(target error) bool {
+	return target == errs.ErrUnknown
+}
+
 // InheritNonExistingTagError indicate that defined heredity does not exist.
 type InheritNonExistingTagError struct {
 	name string
@@ -39,6 +85,31 @@ func (i InheritNonExistingTagError) Error() string {
 	return fmt.Sprintf("defined tag '%s' for heredity does not exist", i.name)
 }
 
+// Name returns the name of the inherited tag.
+func (i InheritNonExistingTagError) Name() string {
+	return i.name
+}
+
+// Code returns the error code.
+func (i InheritNonExistingTagError) Code() string {
+	return errs.CodeUnknownInheritedTag
+}
+
+// Path returns an empty string since tags have no path.
+func (i InheritNonExistingTagError) Path() string {
+	return ""
+}
+
+// Details returns the parameters of the error.
+func (i InheritNonExistingTagError) Details() map[string]interface{} {
+	return map[string]interface{}{"name": i.name}
+}
+
+// Is reports whether the error belongs to given category.
+func (i InheritNonExistingTagError) Is(target error) bool {
+	return target == errs.ErrUnknown
+}
+
 // UnknownPathError indicate that defined path does not exist.
 type UnknownPathError struct {
 	path string
@@ -47,3 +118,23 @@ type UnknownPathError struct {
 func (u UnknownPathError) Error() string {
 	return fmt.Sprintf("defined path '%s' is unknown", u.path)
 }
+
+// Path returns the unknown path.
+func (u UnknownPathError) Path() string {
+	return u.path
+}
+
+// Code returns the error code.
+func (u UnknownPathError) Code() string {
+	return errs.CodeUnknownPath
+}
+
+// Details returns the parameters of the error.
+func (u UnknownPathError) Details() map[string]interface{} {
+	return map[string]interface{}{"path": u.path}
+}
+
+// Is reports whether the error belongs to given category.
+func (u UnknownPathError) Is(target error) bool {
+	return target == errs.ErrUnknown
+}
@@ -3,6 +3,7 @@ package validator
 import (
 	"testing"
 
+	"github.com/StevenCyb/go-mongo-tools/errs"
 	"github.com/stretchr/testify/require"
 )
 
@@ -11,6 +12,7 @@ func TestInvalidTypeError(t *testing.T) {
 
 	require.Equal(t, "type at 'a.b' is invalid",
 		InvalidTypeError{path: "a.b"}.Error())
+	require.Equal(t, "a.b", InvalidTypeError{path: "a.b"}.Path())
 }
 
 func TestUnknownRuleError(t *testing.T) {
@@ -32,4 +34,41 @@ func TestUnknownPathError(t *testing.T) {
 
 	require.Equal(t, "defined path 'test' is unknown",
 		UnknownPathError{path: "test"}.Error())
+	require.Equal(t, "test", UnknownPathError{path: "test"}.Path())
+}
+
+func TestErrorCategories(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		err      errs.Error
+		category error
+		code     string
+		path     string
+	}{
+		{InvalidTypeError{path: "a.b"}, errs.ErrType, errs.CodeInvalidType, "a.b"},
+		{UnknownRuleError{name: "test"}, errs.ErrUnknown, errs.CodeUnknownRule, ""},
+		{InheritNonExistingTagError{name: "test"}, errs.ErrUnknown, errs.CodeUnknownInheritedTag, ""},
+		{UnknownPathError{path: "a.b"}, errs.ErrUnknown, errs.CodeUnknownPath, "a.b"},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.code, testCase.err.Code())
+		require.Equal(t, testCase.path, testCase.err.Path())
+		require.NotEmpty(t, testCase.err.Details())
+		require.ErrorIs(t, testCase.err, testCase.category)
+		require.NotErrorIs(t, testCase.err, errs.ErrPolicy)
+	}
+}
+
+func TestSentinelErrors(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, errs.CodeInvalidTag, ErrMissingPrefix.Code())
+	require.ErrorIs(t, ErrMissingPrefix, errs.ErrSyntax)
+	require.Equal(t, errs.CodeInvalidConfiguration, ErrDuplicateRuleTags.Code())
+	require.ErrorIs(t, ErrDuplicateRuleTags, errs.ErrConstraint)
+	require.Equal(t, errs.CodeNilReference, ErrNilRule.Code())
+	require.Equal(t, errs.CodeNilReference, ErrReferenceIsNil.Code())
+	require.ErrorIs(t, ErrReferenceIsNil, errs.ErrType)
 }
@@ -3,8 +3,10 @@ package validator
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
+	"github.com/StevenCyb/go-mongo-tools/errs"
 	"github.com/StevenCyb/go-mongo-tools/mongo/jsonpatch/forcecast"
 	"github.com/StevenCyb/go-mongo-tools/mongo/jsonpatch/operation"
 	"github.com/StevenCyb/go-mongo-tools/mongo/jsonpatch/rule"
@@ -49,6 +51,16 @@ func (v *Validator) RegisterRule(key string, rule rule.Rule) error {
 
 // Validate a given JSON patch operations again rules.
 func (v Validator) Validate(operationSpec operation.Spec) error {
+	return v.validate(operationSpec, false)
+}
+
+// ValidateAll validates a given JSON patch operation against every rule
+// and returns all violations as `errs.Chain` error.
+func (v Validator) ValidateAll(operationSpec operation.Spec) error {
+	return v.validate(operationSpec, true)
+}
+
+func (v Validator) validate(operationSpec operation.Spec, all bool) error {
 	if forceCast, match := v.forceCast[operationSpec.Path]; match {
 		var err error
 
@@ -58,31 +70,43 @@ func (v Validator) Validate(operationSpec operation.Spec) error {
 		}
 	}
 
-	if rules, match := v.rules[operationSpec.Path]; match {
-		for _, rule := range rules {
-			err := rule.Validate(operationSpec)
-			if err != nil {
-				return fmt.Errorf("operation no allowed: %w", err)
+	rules, match := v.rules[operationSpec.Path]
+	if !match {
+		for path, wildcardRules := range v.wildcardRules {
+			if path.Equal(operationSpec.Path) {
+				rules, match = wildcardRules, true
+
+				break
 			}
 		}
+	}
 
-		return nil
+	if !match {
+		return UnknownPathError{path: string(operationSpec.Path)}
 	}
 
-	for path, rules := range v.wildcardRules {
-		if path.Equal(operationSpec.Path) {
-			for _, rule := range rules {
-				err := rule.Validate(operationSpec)
-				if err != nil {
-					return fmt.Errorf("operation no allowed: %w", err)
-				}
+	names := make([]string, 0, len(rules))
+	for name := range rules {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	errChain := errs.Chain{}
+
+	for _, name := range names {
+		err := rules[name].Validate(operationSpec)
+		if err != nil {
+			err = fmt.Errorf("operation no allowed: %w", err)
+			if !all {
+				return err
 			}
 
-			return nil
+			errChain.AddIf(err)
 		}
 	}
 
-	return UnknownPathError{path: string(operationSpec.Path)}
+	return errChain.GetError()
 }
 
 // UseReference interpret given reference to model rule set.
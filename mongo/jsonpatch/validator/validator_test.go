@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/StevenCyb/go-mongo-tools/errs"
 	"github.com/StevenCyb/go-mongo-tools/mongo/jsonpatch/operation"
 	"github.com/StevenCyb/go-mongo-tools/mongo/jsonpatch/rule"
 	"github.com/stretchr/testify/require"
@@ -785,6 +786,47 @@ func TestValidateTypecheckRule(t *testing.T) {
 	})
 }
 
+func TestValidateAll(t *testing.T) {
+	t.Parallel()
+
+	validator, err := NewValidator(reflect.TypeOf(struct {
+		A string `bson:"a" jp_min:"3" jp_expression:"^\\w+$" jp_op_disallowed:"replace"`
+		B string `bson:"b" jp_min:"3"`
+	}{}))
+	require.NoError(t, err)
+	require.NotNil(t, validator)
+
+	t.Run("Valid_Success", func(t *testing.T) {
+		t.Parallel()
+
+		err := validator.ValidateAll(operation.Spec{Operation: operation.ReplaceOperation, Path: "b", Value: "abc"})
+		require.NoError(t, err)
+	})
+
+	t.Run("AllViolations_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		spec := operation.Spec{Operation: operation.ReplaceOperation, Path: "a", Value: "!"}
+
+		err := validator.ValidateAll(spec)
+		require.Error(t, err)
+
+		violations := errs.Errors(err)
+		require.Len(t, violations, 3)
+		require.ErrorIs(t, violations[0], errs.ErrConstraint)
+		require.ErrorIs(t, violations[1], errs.ErrConstraint)
+		require.ErrorIs(t, violations[2], errs.ErrPolicy)
+		require.Equal(t, violations[0], validator.Validate(spec))
+	})
+
+	t.Run("UnknownPath_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		err := validator.ValidateAll(operation.Spec{Operation: operation.RemoveOperation, Path: "c"})
+		require.Equal(t, UnknownPathError{path: "c"}, err)
+	})
+}
+
 type demoValidateStruct struct { //nolint:govet
 	A int `bson:"a"`
 	B struct {
@@ -0,0 +1,144 @@
+package pagination
+
+import (
+	"fmt"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+)
+
+// InvalidParameterError indicate that a parameter has an invalid value.
+type InvalidParameterError struct {
+	name  string
+	value string
+}
+
+func (i InvalidParameterError) Error() string {
+	return fmt.Sprintf("parameter '%s' has invalid value '%s'", i.name, i.value)
+}
+
+// Path returns the name of the parameter.
+func (i InvalidParameterError) Path() string {
+	return i.name
+}
+
+// Value returns the invalid value.
+func (i InvalidParameterError) Value() string {
+	return i.value
+}
+
+// Code returns the error code.
+func (i InvalidParameterError) Code() string {
+	return errs.CodeInvalidParameter
+}
+
+// Details returns the parameters of the error.
+func (i InvalidParameterError) Details() map[string]interface{} {
+	return map[string]interface{}{"name": i.name, "value": i.value}
+}
+
+// Is reports whether the error belongs to given category.
+func (i InvalidParameterError) Is(target error) bool {
+	return target == errs.ErrParameter || target == errs.ErrType
+}
+
+// TooSmallError indicate that a parameter is below its minimum.
+type TooSmallError struct {
+	name    string
+	value   int64
+	minimum int64
+}
+
+func (t TooSmallError) Error() string {
+	return fmt.Sprintf("parameter '%s' is %d, must be at least %d", t.name, t.value, t.minimum)
+}
+
+// Path returns the name of the parameter.
+func (t TooSmallError) Path() string {
+	return t.name
+}
+
+// Minimum returns the minimum of the parameter.
+func (t TooSmallError) Minimum() int64 {
+	return t.minimum
+}
+
+// Code returns the error code.
+func (t TooSmallError) Code() string {
+	return errs.CodeValueTooSmall
+}
+
+// Details returns the parameters of the error.
+func (t TooSmallError) Details() map[string]interface{} {
+	return map[string]interface{}{"name": t.name, "value": t.value, "minimum": t.minimum}
+}
+
+// Is reports whether the error belongs to given category.
+func (t TooSmallError) Is(target error) bool {
+	return target == errs.ErrParameter || target == errs.ErrConstraint
+}
+
+// TooLargeError indicate that a parameter exceeds its maximum.
+type TooLargeError struct {
+	name    string
+	value   int64
+	maximum int64
+}
+
+func (t TooLargeError) Error() string {
+	return fmt.Sprintf("parameter '%s' is %d, must be at most %d", t.name, t.value, t.maximum)
+}
+
+// Path returns the name of the parameter.
+func (t TooLargeError) Path() string {
+	return t.name
+}
+
+// Maximum returns the maximum of the parameter.
+func (t TooLargeError) Maximum() int64 {
+	return t.maximum
+}
+
+// Code returns the error code.
+func (t TooLargeError) Code() string {
+	return errs.CodeValueTooLarge
+}
+
+// Details returns the parameters of the error.
+func (t TooLargeError) Details() map[string]interface{} {
+	return map[string]interface{}{"name": t.name, "value": t.value, "maximum": t.maximum}
+}
+
+// Is reports whether the error belongs to given category.
+func (t TooLargeError) Is(target error) bool {
+	return target == errs.ErrParameter || target == errs.ErrConstraint
+}
+
+// ConflictingParametersError indicate that two exclusive parameters are given.
+type ConflictingParametersError struct {
+	first  string
+	second string
+}
+
+func (c ConflictingParametersError) Error() string {
+	return fmt.Sprintf("parameters '%s' and '%s' can not be combined", c.first, c.second)
+}
+
+// Path returns the name of the second parameter.
+func (c ConflictingParametersError) Path() string {
+	return c.second
+}
+
+// Code returns the error code.
+func (c ConflictingParametersError) Code() string {
+	return errs.CodeConflictingParameters
+}
+
+// Details returns the parameters of the error.
+func (c ConflictingParametersError) Details() map[string]interface{} {
+	return map[string]interface{}{"first": c.first, "second": c.second}
+}
+
+// Is reports whether the error belongs to given category.
+func (c ConflictingParametersError) Is(target error) bool {
+	return target == errs.ErrParameter
+}
@@ -0,0 +1,45 @@
+package pagination
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvalidParameterError(t *testing.T) {
+	t.Parallel()
+
+	err := InvalidParameterError{name: "limit", value: "ten"}
+	require.Equal(t, "parameter 'limit' has invalid value 'ten'", err.Error())
+	require.Equal(t, "ten", err.Value())
+	require.Equal(t, errs.CodeInvalidParameter, err.Code())
+	require.True(t, errors.Is(err, errs.ErrType))
+}
+
+func TestTooSmallError(t *testing.T) {
+	t.Parallel()
+
+	err := TooSmallError{name: "page", value: 0, minimum: 1}
+	require.Equal(t, "parameter 'page' is 0, must be at least 1", err.Error())
+	require.Equal(t, int64(1), err.Minimum())
+	require.Equal(t, errs.CodeValueTooSmall, err.Code())
+}
+
+func TestTooLargeError(t *testing.T) {
+	t.Parallel()
+
+	err := TooLargeError{name: "limit", value: 500, maximum: 100}
+	require.Equal(t, "parameter 'limit' is 500, must be at most 100", err.Error())
+	require.Equal(t, int64(100), err.Maximum())
+	require.Equal(t, errs.CodeValueTooLarge, err.Code())
+}
+
+func TestConflictingParametersError(t *testing.T) {
+	t.Parallel()
+
+	err := ConflictingParametersError{first: "offset", second: "page"}
+	require.Equal(t, "parameters 'offset' and 'page' can not be combined", err.Error())
+	require.True(t, errors.Is(err, errs.ErrParameter))
+}
@@ -0,0 +1,244 @@
+package pagination
+
+import (
+	"math"
+	"net/url"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Default names of the query parameters.
+const (
+	LimitParameter    = "limit"
+	OffsetParameter   = "offset"
+	PageParameter     = "page"
+	PageSizeParameter = "pageSize"
+	TotalParameter    = "total"
+)
+
+const (
+	// DefaultLimit is the default number of documents per page.
+	DefaultLimit int64 = 20
+	// DefaultMaxLimit is the default maximum number of documents per page.
+	DefaultMaxLimit int64 = 100
+)
+
+// Parameters are the names of the query parameters, empty names are replaced by defaults.
+type Parameters struct {
+	Limit    string
+	Offset   string
+	Page     string
+	PageSize string
+	Total    string
+}
+
+// Config of a parser, zero values are replaced by defaults.
+type Config struct {
+	Parameters Parameters
+	// DefaultLimit is used if neither `limit` nor `pageSize` is given, defaults to `DefaultLimit`.
+	DefaultLimit int64
+	// MaxLimit is the maximum of `limit` and `pageSize`, defaults to `DefaultMaxLimit`.
+	MaxLimit int64
+	// MaxOffset is the maximum of the (computed) offset, zero disables the limit.
+	MaxOffset int64
+	// Clamp reduces a limit above `MaxLimit` to `MaxLimit` instead of failing.
+	Clamp bool
+	// AllowTotal allows requesters to ask for the total count with `total=true`.
+	AllowTotal bool
+}
+
+// NewParser creates a new parser with given configuration.
+func NewParser(config Config) *Parser {
+	if config.DefaultLimit <= 0 {
+		config.DefaultLimit = DefaultLimit
+	}
+
+	if config.MaxLimit <= 0 {
+		config.MaxLimit = DefaultMaxLimit
+	}
+
+	if config.DefaultLimit > config.MaxLimit {
+		config.DefaultLimit = config.MaxLimit
+	}
+
+	for _, name := range []struct {
+		value    *string
+		fallback string
+	}{
+		{&config.Parameters.Limit, LimitParameter},
+		{&config.Parameters.Offset, OffsetParameter},
+		{&config.Parameters.Page, PageParameter},
+		{&config.Parameters.PageSize, PageSizeParameter},
+		{&config.Parameters.Total, TotalParameter},
+	} {
+		if *name.value == "" {
+			*name.value = name.fallback
+		}
+	}
+
+	return &Parser{config: config}
+}
+
+// Parser parses pagination parameters.
+// A parser is safe for concurrent use.
+type Parser struct {
+	config Config
+}
+
+// Pagination is the parsed window of documents.
+type Pagination struct {
+	Limit  int64
+	Offset int64
+	// Total reports whether the requester asked for the total count.
+	Total bool
+}
+
+// Page returns the 1-based page of the window.
+func (p Pagination) Page() int64 {
+	return p.Offset/p.Limit + 1
+}
+
+// FindOptions returns the options to find the documents of the window.
+func (p Pagination) FindOptions() *options.FindOptions {
+	return options.Find().SetLimit(p.Limit).SetSkip(p.Offset)
+}
+
+// CountPipeline returns a pipeline that counts all documents of given filter
+// into a single document `{total: <count>}`.
+func (p Pagination) CountPipeline(filter interface{}) mongo.Pipeline {
+	if filter == nil {
+		filter = bson.D{}
+	}
+
+	return mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$count", Value: "total"}},
+	}
+}
+
+// Envelope returns the response envelope for given documents of the window.
+// The total is omitted if negative.
+func (p Pagination) Envelope(items interface{}, total int64) Envelope {
+	envelope := Envelope{
+		Items:  items,
+		Limit:  p.Limit,
+		Offset: p.Offset,
+		Page:   p.Page(),
+	}
+
+	if total >= 0 {
+		envelope.Total = &total
+	}
+
+	return envelope
+}
+
+// Envelope is the response of a paginated list.
+type Envelope struct {
+	Items  interface{} `json:"items"`
+	Total  *int64      `json:"total,omitempty"`
+	Limit  int64       `json:"limit"`
+	Offset int64       `json:"offset"`
+	Page   int64       `json:"page"`
+}
+
+// Parse the pagination parameters of given query values.
+// `limit` and `pageSize` as well as `offset` and `page` are exclusive.
+func (p *Parser) Parse(values url.Values) (Pagination, error) {
+	var (
+		parameters = p.config.Parameters
+		pagination = Pagination{Limit: p.config.DefaultLimit}
+	)
+
+	name, err := exclusive(values, parameters.Limit, parameters.PageSize)
+	if err != nil {
+		return Pagination{}, err
+	}
+
+	if name != "" {
+		pagination.Limit, err = integer(values, name, 1, p.config.MaxLimit, p.config.Clamp)
+		if err != nil {
+			return Pagination{}, err
+		}
+	}
+
+	name, err = exclusive(values, parameters.Offset, parameters.Page)
+	if err != nil {
+		return Pagination{}, err
+	}
+
+	maxOffset := p.config.MaxOffset
+	if maxOffset <= 0 {
+		maxOffset = math.MaxInt64
+	}
+
+	switch name {
+	case parameters.Offset:
+		pagination.Offset, err = integer(values, name, 0, maxOffset, false)
+	case parameters.Page:
+		var page int64
+
+		// the first page has no offset, the increment is skipped if it would overflow
+		maxPage := maxOffset / pagination.Limit
+		if maxPage < math.MaxInt64 {
+			maxPage++
+		}
+
+		page, err = integer(values, name, 1, maxPage, false)
+		pagination.Offset = (page - 1) * pagination.Limit
+	}
+
+	if err != nil {
+		return Pagination{}, err
+	}
+
+	if p.config.AllowTotal && values.Has(parameters.Total) {
+		pagination.Total, err = strconv.ParseBool(values.Get(parameters.Total))
+		if err != nil {
+			return Pagination{}, InvalidParameterError{name: parameters.Total, value: values.Get(parameters.Total)}
+		}
+	}
+
+	return pagination, nil
+}
+
+// exclusive returns the name of the given parameter or an empty string if none is given.
+func exclusive(values url.Values, first, second string) (string, error) {
+	switch {
+	case values.Has(first) && values.Has(second):
+		return "", ConflictingParametersError{first: first, second: second}
+	case values.Has(first):
+		return first, nil
+	case values.Has(second):
+		return second, nil
+	}
+
+	return "", nil
+}
+
+// integer parses the parameter of given name within given range.
+func integer(values url.Values, name string, minimum, maximum int64, clamp bool) (int64, error) {
+	value := values.Get(name)
+
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, InvalidParameterError{name: name, value: value}
+	}
+
+	if number < minimum {
+		return 0, TooSmallError{name: name, value: number, minimum: minimum}
+	}
+
+	if number > maximum {
+		if clamp {
+			return maximum, nil
+		}
+
+		return 0, TooLargeError{name: name, value: number, maximum: maximum}
+	}
+
+	return number, nil
+}
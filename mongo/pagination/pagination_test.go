@@ -0,0 +1,185 @@
+//nolint:funlen
+package pagination
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func parse(t *testing.T, parser *Parser, query string) (Pagination, error) {
+	t.Helper()
+
+	values, err := url.ParseQuery(query)
+	require.NoError(t, err)
+
+	return parser.Parse(values)
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("WithDefaults_Success", func(t *testing.T) {
+		t.Parallel()
+
+		pagination, err := parse(t, NewParser(Config{}), "")
+		require.NoError(t, err)
+		require.Equal(t, Pagination{Limit: DefaultLimit}, pagination)
+		require.Equal(t, int64(1), pagination.Page())
+
+		pagination, err = parse(t, NewParser(Config{DefaultLimit: 500, MaxLimit: 50}), "")
+		require.NoError(t, err)
+		require.Equal(t, Pagination{Limit: 50}, pagination)
+	})
+
+	t.Run("WithLimitAndOffset_Success", func(t *testing.T) {
+		t.Parallel()
+
+		pagination, err := parse(t, NewParser(Config{}), "limit=10&offset=30")
+		require.NoError(t, err)
+		require.Equal(t, Pagination{Limit: 10, Offset: 30}, pagination)
+		require.Equal(t, int64(4), pagination.Page())
+		require.Equal(t, options.Find().SetLimit(10).SetSkip(30), pagination.FindOptions())
+	})
+
+	t.Run("WithPageAndPageSize_Success", func(t *testing.T) {
+		t.Parallel()
+
+		pagination, err := parse(t, NewParser(Config{}), "page=3&pageSize=25")
+		require.NoError(t, err)
+		require.Equal(t, Pagination{Limit: 25, Offset: 50}, pagination)
+		require.Equal(t, int64(3), pagination.Page())
+	})
+
+	t.Run("WithPageOfSingleDocument_Success", func(t *testing.T) {
+		t.Parallel()
+
+		for page, offset := range map[string]int64{"1": 0, "2": 1, "1000": 999, "9223372036854775807": 9223372036854775806} {
+			pagination, err := parse(t, NewParser(Config{}), "limit=1&page="+page)
+			require.NoError(t, err)
+			require.Equal(t, Pagination{Limit: 1, Offset: offset}, pagination)
+		}
+
+		pagination, err := parse(t, NewParser(Config{}), "limit=2&page=4611686018427387904")
+		require.NoError(t, err)
+		require.Equal(t, Pagination{Limit: 2, Offset: 9223372036854775806}, pagination)
+
+		_, err = parse(t, NewParser(Config{}), "limit=2&page=4611686018427387905")
+		require.True(t, errors.Is(err, errs.ErrConstraint))
+	})
+
+	t.Run("WithCustomParameters_Success", func(t *testing.T) {
+		t.Parallel()
+
+		parser := NewParser(Config{Parameters: Parameters{Limit: "per_page", Page: "p"}})
+
+		pagination, err := parse(t, parser, "per_page=5&p=2&limit=1000")
+		require.NoError(t, err)
+		require.Equal(t, Pagination{Limit: 5, Offset: 5}, pagination)
+
+		_, err = parse(t, parser, "per_page=500")
+		require.Equal(t, TooLargeError{name: "per_page", value: 500, maximum: DefaultMaxLimit}, err)
+	})
+
+	t.Run("WithClamp_Success", func(t *testing.T) {
+		t.Parallel()
+
+		pagination, err := parse(t, NewParser(Config{MaxLimit: 50, Clamp: true}), "limit=1000")
+		require.NoError(t, err)
+		require.Equal(t, Pagination{Limit: 50}, pagination)
+	})
+
+	t.Run("WithTotal_Success", func(t *testing.T) {
+		t.Parallel()
+
+		pagination, err := parse(t, NewParser(Config{AllowTotal: true}), "total=true")
+		require.NoError(t, err)
+		require.True(t, pagination.Total)
+
+		pagination, err = parse(t, NewParser(Config{}), "total=true")
+		require.NoError(t, err)
+		require.False(t, pagination.Total)
+	})
+
+	t.Run("WithInvalidValue_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := parse(t, NewParser(Config{}), "limit=ten")
+		require.Equal(t, InvalidParameterError{name: LimitParameter, value: "ten"}, err)
+
+		_, err = parse(t, NewParser(Config{AllowTotal: true}), "total=maybe")
+		require.Equal(t, InvalidParameterError{name: TotalParameter, value: "maybe"}, err)
+		require.True(t, errors.Is(err, errs.ErrParameter))
+	})
+
+	t.Run("WithOutOfRange_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := parse(t, NewParser(Config{}), "limit=0")
+		require.Equal(t, TooSmallError{name: LimitParameter, value: 0, minimum: 1}, err)
+
+		_, err = parse(t, NewParser(Config{}), "offset=-1")
+		require.Equal(t, TooSmallError{name: OffsetParameter, value: -1, minimum: 0}, err)
+
+		_, err = parse(t, NewParser(Config{}), "page=0")
+		require.Equal(t, TooSmallError{name: PageParameter, value: 0, minimum: 1}, err)
+
+		_, err = parse(t, NewParser(Config{}), "pageSize=101")
+		require.Equal(t, TooLargeError{name: PageSizeParameter, value: 101, maximum: DefaultMaxLimit}, err)
+
+		_, err = parse(t, NewParser(Config{MaxOffset: 1000}), "offset=1001")
+		require.Equal(t, TooLargeError{name: OffsetParameter, value: 1001, maximum: 1000}, err)
+
+		_, err = parse(t, NewParser(Config{MaxOffset: 1000}), "page=52&pageSize=20")
+		require.Equal(t, TooLargeError{name: PageParameter, value: 52, maximum: 51}, err)
+
+		_, err = parse(t, NewParser(Config{}), "page=9223372036854775807&limit=100")
+		require.ErrorIs(t, err, errs.ErrConstraint)
+	})
+
+	t.Run("WithConflictingParameters_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := parse(t, NewParser(Config{}), "limit=10&pageSize=10")
+		require.Equal(t, ConflictingParametersError{first: LimitParameter, second: PageSizeParameter}, err)
+
+		_, err = parse(t, NewParser(Config{}), "offset=10&page=2")
+		require.Equal(t, ConflictingParametersError{first: OffsetParameter, second: PageParameter}, err)
+	})
+}
+
+func TestCountPipeline(t *testing.T) {
+	t.Parallel()
+
+	filter := bson.D{{Key: "age", Value: bson.D{{Key: "$gt", Value: 18}}}}
+	require.Equal(t, mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$count", Value: "total"}},
+	}, Pagination{Limit: 10}.CountPipeline(filter))
+
+	require.Equal(t, mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{}}},
+		{{Key: "$count", Value: "total"}},
+	}, Pagination{Limit: 10}.CountPipeline(nil))
+}
+
+func TestEnvelope(t *testing.T) {
+	t.Parallel()
+
+	pagination := Pagination{Limit: 2, Offset: 2}
+
+	data, err := json.Marshal(pagination.Envelope([]string{"c", "d"}, 5))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"items":["c","d"],"total":5,"limit":2,"offset":2,"page":2}`, string(data))
+
+	data, err = json.Marshal(pagination.Envelope([]string{"c", "d"}, -1))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"items":["c","d"],"limit":2,"offset":2,"page":2}`, string(data))
+}
@@ -0,0 +1,113 @@
+package projection
+
+import (
+	"fmt"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+)
+
+// MixedProjectionError indicate that a projection mixes inclusion and exclusion.
+type MixedProjectionError struct {
+	errs.Diagnostic
+	previous string
+}
+
+func (m MixedProjectionError) Error() string {
+	return fmt.Sprintf("field '%s' can not be combined with '%s', projections either include or exclude fields",
+		m.Actual, m.previous)
+}
+
+// Previous returns the previous field with the conflicting mode.
+func (m MixedProjectionError) Previous() string {
+	return m.previous
+}
+
+// Code returns the error code.
+func (m MixedProjectionError) Code() string {
+	return errs.CodeMixedProjection
+}
+
+// Path returns the conflicting field.
+func (m MixedProjectionError) Path() string {
+	return m.Actual
+}
+
+// Details returns the parameters of the error.
+func (m MixedProjectionError) Details() map[string]interface{} {
+	return map[string]interface{}{"field": m.Actual, "previous": m.previous}
+}
+
+// Is reports whether the error belongs to given category.
+func (m MixedProjectionError) Is(target error) bool {
+	return target == errs.ErrSyntax
+}
+
+// PathCollisionError indicate that a path is projected twice or overlaps with a previous path like `a` and `a.b`.
+type PathCollisionError struct {
+	errs.Diagnostic
+	previous string
+}
+
+func (p PathCollisionError) Error() string {
+	return fmt.Sprintf("path '%s' collides with '%s'", p.Actual, p.previous)
+}
+
+// Previous returns the previous path that collides.
+func (p PathCollisionError) Previous() string {
+	return p.previous
+}
+
+// Code returns the error code.
+func (p PathCollisionError) Code() string {
+	return errs.CodePathCollision
+}
+
+// Path returns the colliding path.
+func (p PathCollisionError) Path() string {
+	return p.Actual
+}
+
+// Details returns the parameters of the error.
+func (p PathCollisionError) Details() map[string]interface{} {
+	return map[string]interface{}{"field": p.Actual, "previous": p.previous}
+}
+
+// Is reports whether the error belongs to given category.
+func (p PathCollisionError) Is(target error) bool {
+	return target == errs.ErrSyntax
+}
+
+// InvalidSliceError indicate that a value of a slice is out of range.
+type InvalidSliceError struct {
+	field string
+	value string
+}
+
+func (i InvalidSliceError) Error() string {
+	return fmt.Sprintf("slice value '%s' of '%s' is invalid", i.value, i.field)
+}
+
+// Path returns the field of the slice.
+func (i InvalidSliceError) Path() string {
+	return i.field
+}
+
+// Value returns the invalid value.
+func (i InvalidSliceError) Value() string {
+	return i.value
+}
+
+// Code returns the error code.
+func (i InvalidSliceError) Code() string {
+	return errs.CodeInvalidSlice
+}
+
+// Details returns the parameters of the error.
+func (i InvalidSliceError) Details() map[string]interface{} {
+	return map[string]interface{}{"field": i.field, "value": i.value}
+}
+
+// Is reports whether the error belongs to given category.
+func (i InvalidSliceError) Is(target error) bool {
+	return target == errs.ErrConstraint
+}
@@ -0,0 +1,48 @@
+package projection
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMixedProjectionError(t *testing.T) {
+	t.Parallel()
+
+	err := MixedProjectionError{Diagnostic: errs.NewDiagnostic("name,-age", 6, 3, "age"), previous: "name"}
+	require.Equal(t, "field 'age' can not be combined with 'name', projections either include or exclude fields",
+		err.Error())
+	require.Equal(t, "age", err.Path())
+	require.Equal(t, "name", err.Previous())
+	require.True(t, errors.Is(err, errs.ErrSyntax))
+
+	var diagnosticErr errs.DiagnosticError
+	require.True(t, errors.As(err, &diagnosticErr))
+	require.Equal(t, 6, diagnosticErr.GetDiagnostic().Position)
+}
+
+func TestPathCollisionError(t *testing.T) {
+	t.Parallel()
+
+	err := PathCollisionError{Diagnostic: errs.NewDiagnostic("a,a.b", 2, 3, "a.b"), previous: "a"}
+	require.Equal(t, "path 'a.b' collides with 'a'", err.Error())
+	require.Equal(t, "a.b", err.Path())
+	require.Equal(t, "a", err.Previous())
+	require.Equal(t, errs.CodePathCollision, err.Code())
+	require.True(t, errors.Is(err, errs.ErrSyntax))
+
+	var diagnosticErr errs.DiagnosticError
+	require.True(t, errors.As(err, &diagnosticErr))
+	require.Equal(t, 2, diagnosticErr.GetDiagnostic().Position)
+}
+
+func TestInvalidSliceError(t *testing.T) {
+	t.Parallel()
+
+	err := InvalidSliceError{field: "comments", value: "0"}
+	require.Equal(t, "slice value '0' of 'comments' is invalid", err.Error())
+	require.Equal(t, "0", err.Value())
+	require.True(t, errors.Is(err, errs.ErrConstraint))
+}
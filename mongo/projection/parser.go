@@ -0,0 +1,352 @@
+package projection
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+	"github.com/StevenCyb/go-mongo-tools/tokenizer"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Types that are used in this parser.
+const (
+	SkipType       tokenizer.Type = "SKIP"
+	AndType        tokenizer.Type = ","
+	ExcludeType    tokenizer.Type = "-"
+	SliceStartType tokenizer.Type = "["
+	SliceEndType   tokenizer.Type = "]"
+	RangeType      tokenizer.Type = ":"
+	NumberType     tokenizer.Type = "NUMBER"
+	FieldNameType  tokenizer.Type = "FIELD_NAME"
+)
+
+// idField is the only field that can be excluded in inclusion projections and vice versa.
+const idField = "_id"
+
+// specialEncode is the map for encoding
+// a list of special characters.
+//
+//nolint:gochecknoglobals
+var specialEncode = map[string]string{
+	`,`: "%5C%2C",
+	` `: "%20",
+}
+
+// NewParser creates a new parser.
+func NewParser(policy tokenizer.FieldPolicy) *Parser {
+	return &Parser{
+		policy: policy,
+	}
+}
+
+// Parser provides the logic to parse projections.
+// It only holds the field policy and can be shared by goroutines.
+type Parser struct {
+	policy tokenizer.FieldPolicy
+}
+
+// parseState holds the state of a single parsing.
+type parseState struct {
+	tokenizer *tokenizer.Tokenizer
+	lookahead *tokenizer.Token
+	// included is the first included field and excluded the first excluded field except `_id`.
+	included *tokenizer.Token
+	excluded *tokenizer.Token
+	// paths are the fields of the previous statements.
+	paths []*tokenizer.Token
+}
+
+// eat return a token with expected type.
+func (p *parseState) eat(tokenType tokenizer.Type) (*tokenizer.Token, error) {
+	token := p.lookahead
+
+	if token == nil || token.Type != tokenType {
+		return nil, p.tokenizer.Unexpected(p.lookahead, tokenType)
+	}
+
+	return token, p.next()
+}
+
+// next fetches the next token as lookahead.
+func (p *parseState) next() error {
+	var err error
+
+	p.lookahead, err = p.tokenizer.GetNextToken()
+
+	return err //nolint:wrapcheck
+}
+
+// Parse a given query.
+func (p *Parser) Parse(query string) (bson.D, error) {
+	return p.ParseContext(context.Background(), query)
+}
+
+// ParseContext parses a given query and passes the context to the policy.
+func (p *Parser) ParseContext(ctx context.Context, query string) (bson.D, error) {
+	if query == "" {
+		return bson.D{}, nil
+	}
+
+	for dec, enc := range specialEncode {
+		query = strings.ReplaceAll(query, enc, dec)
+	}
+
+	state := &parseState{
+		tokenizer: tokenizer.NewScannerTokenizer(query, SkipType, FieldNameType, scan, p.policy).WithContext(ctx),
+	}
+
+	err := state.next()
+	if err != nil {
+		return nil, err
+	}
+
+	return state.expression()
+}
+
+/*
+ * <expression>
+ *   | <statement>
+ *   | <statement> "," <expression>
+ * .
+ */
+func (p *parseState) expression() ([]bson.E, error) {
+	statements := []bson.E{}
+
+	if p.lookahead == nil {
+		return nil, p.tokenizer.Unexpected(p.lookahead, ExcludeType, FieldNameType)
+	}
+
+	statement, err := p.statement()
+	if err != nil {
+		return nil, err
+	}
+
+	statements = append(statements, *statement)
+
+	if p.lookahead != nil {
+		_, err := p.eat(AndType)
+		if err != nil {
+			return nil, err
+		}
+
+		nextStatements, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+
+		statements = append(statements, nextStatements...)
+	}
+
+	return statements, nil
+}
+
+/*
+ * <statement>
+ *   : <key>
+ *   | "-" <key>
+ *   | <key> "[" <slice> "]"
+ * .
+ */
+func (p *parseState) statement() (*bson.E, error) {
+	if p.lookahead.Type == ExcludeType || p.isNegativeNumber() {
+		keyToken, err := p.excludedKey()
+		if err != nil {
+			return nil, err
+		}
+
+		return p.mode(keyToken, false)
+	}
+
+	keyToken, err := p.key()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.lookahead == nil || p.lookahead.Type != SliceStartType {
+		return p.mode(keyToken, true)
+	}
+
+	err = p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	slice, err := p.slice(keyToken)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.eat(SliceEndType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bson.E{Key: keyToken.Value, Value: bson.D{{Key: "$slice", Value: slice}}}, nil
+}
+
+/*
+ * <key>
+ *   : <field_name>
+ *   | <number>
+ * .
+ */
+func (p *parseState) key() (*tokenizer.Token, error) {
+	var (
+		keyToken *tokenizer.Token
+		err      error
+	)
+
+	if p.lookahead != nil && p.lookahead.Type == NumberType && !p.isNegativeNumber() {
+		keyToken, err = p.numericKey(*p.lookahead)
+	} else {
+		keyToken, err = p.eat(FieldNameType)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return keyToken, p.claim(keyToken)
+}
+
+// excludedKey returns the key after the `-`, which is part of the number for all-digit keys like `-0`.
+func (p *parseState) excludedKey() (*tokenizer.Token, error) {
+	if p.lookahead.Type == ExcludeType {
+		err := p.next()
+		if err != nil {
+			return nil, err
+		}
+
+		return p.key()
+	}
+
+	keyToken := *p.lookahead
+	keyToken.Value = keyToken.Value[1:]
+	keyToken.Start++
+	keyToken.Column++
+
+	numericKey, err := p.numericKey(keyToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return numericKey, p.claim(numericKey)
+}
+
+// numericKey returns an all-digit number token as field name like the index `0` of an array.
+// The scanner does not know the position of a number, so the policy is checked here.
+func (p *parseState) numericKey(keyToken tokenizer.Token) (*tokenizer.Token, error) {
+	keyToken.Type = FieldNameType
+
+	err := p.tokenizer.CheckPolicy(&keyToken)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return &keyToken, p.next()
+}
+
+// isNegativeNumber reports whether the lookahead is a negative number, which is an excluded key in key position.
+func (p *parseState) isNegativeNumber() bool {
+	return p.lookahead.Type == NumberType && p.lookahead.Value[0] == '-'
+}
+
+// claim rejects a path that equals or overlaps with the path of a previous statement, like MongoDB does.
+func (p *parseState) claim(keyToken *tokenizer.Token) error {
+	for _, previous := range p.paths {
+		if collides(keyToken.Value, previous.Value) {
+			return PathCollisionError{
+				Diagnostic: errs.NewDiagnostic(p.tokenizer.GetQuery(), keyToken.Start, len(keyToken.Value), keyToken.Value),
+				previous:   previous.Value,
+			}
+		}
+	}
+
+	p.paths = append(p.paths, keyToken)
+
+	return nil
+}
+
+// collides reports whether both paths are equal or one is the parent of the other.
+func collides(path, other string) bool {
+	if len(path) > len(other) {
+		path, other = other, path
+	}
+
+	return path == other || strings.HasPrefix(other, path+".")
+}
+
+/*
+ * <slice>
+ *   : <number>
+ *   | <number> ":" <number>
+ * .
+ */
+func (p *parseState) slice(keyToken *tokenizer.Token) (interface{}, error) {
+	countToken, err := p.eat(NumberType)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := strconv.Atoi(countToken.Value)
+	if err != nil {
+		return nil, InvalidSliceError{field: keyToken.Value, value: countToken.Value}
+	}
+
+	if p.lookahead == nil || p.lookahead.Type != RangeType {
+		return count, nil
+	}
+
+	err = p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	limitToken, err := p.eat(NumberType)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := strconv.Atoi(limitToken.Value)
+	if err != nil || limit <= 0 {
+		return nil, InvalidSliceError{field: keyToken.Value, value: limitToken.Value}
+	}
+
+	return bson.A{count, limit}, nil
+}
+
+// mode returns the inclusion or exclusion of a field and rejects mixing both except for `_id`.
+func (p *parseState) mode(keyToken *tokenizer.Token, include bool) (*bson.E, error) {
+	if keyToken.Value != idField {
+		if include {
+			if p.excluded != nil {
+				return nil, p.mixed(keyToken, p.excluded)
+			}
+
+			p.included = keyToken
+		} else {
+			if p.included != nil {
+				return nil, p.mixed(keyToken, p.included)
+			}
+
+			p.excluded = keyToken
+		}
+	}
+
+	value := 0
+	if include {
+		value = 1
+	}
+
+	return &bson.E{Key: keyToken.Value, Value: value}, nil
+}
+
+// mixed returns the error for a field that conflicts with the mode of a previous field.
+func (p *parseState) mixed(keyToken, previous *tokenizer.Token) error {
+	return MixedProjectionError{
+		Diagnostic: errs.NewDiagnostic(p.tokenizer.GetQuery(), keyToken.Start, len(keyToken.Value), keyToken.Value),
+		previous:   previous.Value,
+	}
+}
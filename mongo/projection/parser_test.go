@@ -0,0 +1,269 @@
+//nolint:funlen
+package projection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+	testutil "github.com/StevenCyb/go-mongo-tools/mongo/test_util"
+	"github.com/StevenCyb/go-mongo-tools/tokenizer"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestParsing(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Query", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("WithEmptyQuery_Success", func(t *testing.T) {
+			t.Parallel()
+
+			testutil.ExecuteSuccessTest(t, NewParser(nil), "", bson.D{})
+		})
+
+		t.Run("WithInclusion_Success", func(t *testing.T) {
+			t.Parallel()
+
+			testutil.ExecuteSuccessTest(t,
+				NewParser(nil),
+				"name, address.city,-_id",
+				bson.D{
+					bson.E{Key: "name", Value: 1},
+					bson.E{Key: "address.city", Value: 1},
+					bson.E{Key: "_id", Value: 0},
+				},
+			)
+		})
+
+		t.Run("WithExclusion_Success", func(t *testing.T) {
+			t.Parallel()
+
+			testutil.ExecuteSuccessTest(t,
+				NewParser(nil),
+				"-internal,-address.zip-code,_id",
+				bson.D{
+					bson.E{Key: "internal", Value: 0},
+					bson.E{Key: "address.zip-code", Value: 0},
+					bson.E{Key: "_id", Value: 1},
+				},
+			)
+		})
+
+		t.Run("WithSlice_Success", func(t *testing.T) {
+			t.Parallel()
+
+			testutil.ExecuteSuccessTest(t,
+				NewParser(nil),
+				"name,comments[5],tags[-3],history[ 20 : 10 ]",
+				bson.D{
+					bson.E{Key: "name", Value: 1},
+					bson.E{Key: "comments", Value: bson.D{{Key: "$slice", Value: 5}}},
+					bson.E{Key: "tags", Value: bson.D{{Key: "$slice", Value: -3}}},
+					bson.E{Key: "history", Value: bson.D{{Key: "$slice", Value: bson.A{20, 10}}}},
+				},
+			)
+			testutil.ExecuteSuccessTest(t,
+				NewParser(nil),
+				"-internal,comments[-5:2]",
+				bson.D{
+					bson.E{Key: "internal", Value: 0},
+					bson.E{Key: "comments", Value: bson.D{{Key: "$slice", Value: bson.A{-5, 2}}}},
+				},
+			)
+		})
+
+		t.Run("WithNumericField_Success", func(t *testing.T) {
+			t.Parallel()
+
+			testutil.ExecuteSuccessTest(t,
+				NewParser(nil),
+				"0,items.1, 2[3]",
+				bson.D{
+					bson.E{Key: "0", Value: 1},
+					bson.E{Key: "items.1", Value: 1},
+					bson.E{Key: "2", Value: bson.D{{Key: "$slice", Value: 3}}},
+				},
+			)
+			testutil.ExecuteSuccessTest(t,
+				NewParser(nil),
+				"-0,- 1,_id",
+				bson.D{
+					bson.E{Key: "0", Value: 0},
+					bson.E{Key: "1", Value: 0},
+					bson.E{Key: "_id", Value: 1},
+				},
+			)
+		})
+
+		t.Run("WithDistinctPaths_Success", func(t *testing.T) {
+			t.Parallel()
+
+			testutil.ExecuteSuccessTest(t,
+				NewParser(nil),
+				"a.b,ab,a.c,b.a",
+				bson.D{
+					bson.E{Key: "a.b", Value: 1},
+					bson.E{Key: "ab", Value: 1},
+					bson.E{Key: "a.c", Value: 1},
+					bson.E{Key: "b.a", Value: 1},
+				},
+			)
+		})
+
+		t.Run("WithPathCollision_Fail", func(t *testing.T) {
+			t.Parallel()
+
+			testutil.ExecuteFailedTest(t,
+				NewParser(nil),
+				"a,a",
+				PathCollisionError{Diagnostic: errs.NewDiagnostic("a,a", 2, 1, "a"), previous: "a"},
+			)
+			testutil.ExecuteFailedTest(t,
+				NewParser(nil),
+				"a,a.b",
+				PathCollisionError{Diagnostic: errs.NewDiagnostic("a,a.b", 2, 3, "a.b"), previous: "a"},
+			)
+			testutil.ExecuteFailedTest(t,
+				NewParser(nil),
+				"-a.b.c,-a.b",
+				PathCollisionError{Diagnostic: errs.NewDiagnostic("-a.b.c,-a.b", 8, 3, "a.b"), previous: "a.b.c"},
+			)
+			testutil.ExecuteFailedTest(t,
+				NewParser(nil),
+				"comments[5],comments",
+				PathCollisionError{Diagnostic: errs.NewDiagnostic("comments[5],comments", 12, 8, "comments"), previous: "comments"},
+			)
+			testutil.ExecuteFailedTest(t,
+				NewParser(nil),
+				"_id,-0,-_id",
+				PathCollisionError{Diagnostic: errs.NewDiagnostic("_id,-0,-_id", 8, 3, "_id"), previous: "_id"},
+			)
+			testutil.ExecuteFailedTest(t,
+				NewParser(nil),
+				"-0,-0",
+				PathCollisionError{Diagnostic: errs.NewDiagnostic("-0,-0", 4, 1, "0"), previous: "0"},
+			)
+		})
+
+		t.Run("WithMixedProjection_Fail", func(t *testing.T) {
+			t.Parallel()
+
+			testutil.ExecuteFailedTest(t,
+				NewParser(nil),
+				"name,-internal",
+				MixedProjectionError{Diagnostic: errs.NewDiagnostic("name,-internal", 6, 8, "internal"), previous: "name"},
+			)
+			testutil.ExecuteFailedTest(t,
+				NewParser(nil),
+				"-_id,-internal,name",
+				MixedProjectionError{Diagnostic: errs.NewDiagnostic("-_id,-internal,name", 15, 4, "name"), previous: "internal"},
+			)
+		})
+
+		t.Run("WithInvalidSlice_Fail", func(t *testing.T) {
+			t.Parallel()
+
+			testutil.ExecuteFailedTest(t,
+				NewParser(nil),
+				"comments[5:0]",
+				InvalidSliceError{field: "comments", value: "0"},
+			)
+			testutil.ExecuteFailedTest(t,
+				NewParser(nil),
+				"comments[99999999999999999999]",
+				InvalidSliceError{field: "comments", value: "99999999999999999999"},
+			)
+		})
+
+		t.Run("WithIncompleteSlice_Fail", func(t *testing.T) {
+			t.Parallel()
+
+			testutil.ExecuteFailedTest(t,
+				NewParser(nil),
+				"comments[5",
+				errs.NewErrUnexpectedInputEndWithDiagnostic(errs.NewDiagnostic("comments[5", 10, 0, "", "]")),
+			)
+			testutil.ExecuteFailedTest(t,
+				NewParser(nil),
+				"comments[a]",
+				errs.NewErrUnexpectedTokenTypeWithDiagnostic(errs.NewDiagnostic(
+					"comments[a]", 9, 1, "FIELD_NAME", "NUMBER")),
+			)
+		})
+
+		t.Run("WithMissingField_Fail", func(t *testing.T) {
+			t.Parallel()
+
+			testutil.ExecuteFailedTest(t,
+				NewParser(nil),
+				"name,",
+				errs.NewErrUnexpectedInputEndWithDiagnostic(errs.NewDiagnostic("name,", 5, 0, "", "-", "FIELD_NAME")),
+			)
+			testutil.ExecuteFailedTest(t,
+				NewParser(nil),
+				"--name",
+				errs.NewErrUnexpectedTokenTypeWithDiagnostic(errs.NewDiagnostic("--name", 1, 1, "-", "FIELD_NAME")),
+			)
+			testutil.ExecuteFailedTest(t,
+				NewParser(nil),
+				"-name[5]",
+				errs.NewErrUnexpectedTokenTypeWithDiagnostic(errs.NewDiagnostic("-name[5]", 5, 1, "[", ",")),
+			)
+		})
+	})
+
+	t.Run("WithPolicy", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("WithAllowedFieldName_Success", func(t *testing.T) {
+			t.Parallel()
+
+			testutil.ExecuteSuccessTest(t,
+				NewParser(tokenizer.NewPolicy(tokenizer.BlacklistPolicy, "password")),
+				"name,comments[3]",
+				bson.D{
+					bson.E{Key: "name", Value: 1},
+					bson.E{Key: "comments", Value: bson.D{{Key: "$slice", Value: 3}}},
+				},
+			)
+		})
+
+		t.Run("WithDisallowedFieldName_Fail", func(t *testing.T) {
+			t.Parallel()
+
+			testutil.ExecuteFailedTest(t,
+				NewParser(tokenizer.NewPolicy(tokenizer.BlacklistPolicy, "password")),
+				"-name,-password",
+				errs.NewErrPolicyViolationWithDiagnostic(errs.NewDiagnostic("-name,-password", 7, 8, "password")),
+			)
+			testutil.ExecuteFailedTest(t,
+				NewParser(tokenizer.NewPolicy(tokenizer.WhitelistPolicy, "name")),
+				"name,-0",
+				errs.NewErrPolicyViolationWithDiagnostic(errs.NewDiagnostic("name,-0", 6, 1, "0")),
+			)
+		})
+
+		t.Run("WithContextPolicy_Fail", func(t *testing.T) {
+			t.Parallel()
+
+			type roleKey struct{}
+
+			policy := tokenizer.Dynamic(func(ctx context.Context) tokenizer.FieldPolicy {
+				if ctx.Value(roleKey{}) == "admin" {
+					return nil
+				}
+
+				return tokenizer.NewPolicy(tokenizer.WhitelistPolicy, "name")
+			})
+
+			_, err := NewParser(policy).ParseContext(context.WithValue(context.Background(), roleKey{}, "admin"), "email")
+			require.NoError(t, err)
+
+			_, err = NewParser(policy).Parse("email")
+			require.ErrorIs(t, err, errs.ErrPolicy)
+		})
+	})
+}
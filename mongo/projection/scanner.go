@@ -0,0 +1,59 @@
+package projection
+
+import (
+	"github.com/StevenCyb/go-mongo-tools/tokenizer"
+)
+
+// scan returns the projection token at the start of given input,
+// words end at `,`, `[`, `]`, `:` or whitespace.
+func scan(input string) (tokenizer.Type, int) {
+	if length := tokenizer.ScanSpace(input); length > 0 {
+		return SkipType, length
+	}
+
+	length := 0
+
+	switch input[0] {
+	case ',':
+		return AndType, 1
+	case '[':
+		return SliceStartType, 1
+	case ']':
+		return SliceEndType, 1
+	case ':':
+		return RangeType, 1
+	}
+
+	for length < len(input) && !isDelimiter(input[length]) {
+		length++
+	}
+
+	if isNumber(input[:length]) {
+		return NumberType, length
+	}
+
+	if input[0] == '-' {
+		return ExcludeType, 1
+	}
+
+	return FieldNameType, length
+}
+
+// isNumber reports whether given value is an optionally negative integer.
+func isNumber(value string) bool {
+	if value != "" && value[0] == '-' {
+		value = value[1:]
+	}
+
+	for i := 0; i < len(value); i++ {
+		if !tokenizer.IsDigit(value[i]) {
+			return false
+		}
+	}
+
+	return value != ""
+}
+
+func isDelimiter(character byte) bool {
+	return character == ',' || character == '[' || character == ']' || character == ':' || tokenizer.IsSpace(character)
+}
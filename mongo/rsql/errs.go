@@ -0,0 +1,280 @@
+package rsql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+)
+
+var (
+	ErrInvalidMacroName = errs.NewErrSentinel(errs.ErrSyntax, errs.CodeInvalidConfiguration, "invalid macro name")
+	ErrDuplicateMacro   = errs.NewErrSentinel(errs.ErrConstraint, errs.CodeInvalidConfiguration, "macro name already registered")
+	ErrEmptyMacro       = errs.NewErrSentinel(errs.ErrSyntax, errs.CodeInvalidConfiguration, "macro expression is empty")
+	ErrNilTransformer   = errs.NewErrSentinel(errs.ErrType, errs.CodeNilReference, "transformer is nil")
+)
+
+// InvalidLiteralError indicate that a literal can not be converted into a value,
+// e.g. a malformed `$oid` or an integer out of range.
+type InvalidLiteralError struct {
+	errs.Diagnostic
+	err  error
+	kind string
+}
+
+func (i InvalidLiteralError) Error() string {
+	return fmt.Sprintf("invalid %s literal '%s': %s", i.kind, i.Actual, i.err)
+}
+
+// Kind returns the kind of the literal like `int`, `float` or `$oid`.
+func (i InvalidLiteralError) Kind() string {
+	return i.kind
+}
+
+// Unwrap returns the reason why the literal is invalid.
+func (i InvalidLiteralError) Unwrap() error {
+	return i.err
+}
+
+// Code returns the error code.
+func (i InvalidLiteralError) Code() string {
+	return errs.CodeInvalidLiteral
+}
+
+// Path returns an empty string since literals have no path.
+func (i InvalidLiteralError) Path() string {
+	return ""
+}
+
+// Details returns the parameters of the error.
+func (i InvalidLiteralError) Details() map[string]interface{} {
+	return map[string]interface{}{"kind": i.kind, "actual": i.Actual, "position": i.Position}
+}
+
+// Is reports whether the error belongs to given category.
+func (i InvalidLiteralError) Is(target error) bool {
+	return target == errs.ErrSyntax
+}
+
+// UnboundParameterError indicate that a placeholder has no parameter value.
+type UnboundParameterError struct {
+	name string
+}
+
+func (u UnboundParameterError) Error() string {
+	return fmt.Sprintf("parameter ':%s' is not bound", u.name)
+}
+
+// Name returns the name of the unbound parameter.
+func (u UnboundParameterError) Name() string {
+	return u.name
+}
+
+// Code returns the error code.
+func (u UnboundParameterError) Code() string {
+	return errs.CodeUnboundParameter
+}
+
+// Path returns an empty string since parameters have no path.
+func (u UnboundParameterError) Path() string {
+	return ""
+}
+
+// Details returns the parameters of the error.
+func (u UnboundParameterError) Details() map[string]interface{} {
+	return map[string]interface{}{"name": u.name}
+}
+
+// Is reports whether the error belongs to given category.
+func (u UnboundParameterError) Is(target error) bool {
+	return target == errs.ErrParameter
+}
+
+// ParameterTypeError indicate that a parameter value has an invalid type.
+type ParameterTypeError struct {
+	name     string
+	expected string
+	actual   string
+}
+
+func (p ParameterTypeError) Error() string {
+	return fmt.Sprintf("parameter ':%s' has invalid type '%s', must be %s", p.name, p.actual, p.expected)
+}
+
+// Name returns the name of the parameter.
+func (p ParameterTypeError) Name() string {
+	return p.name
+}
+
+// Expected returns the expected kind of value.
+func (p ParameterTypeError) Expected() string {
+	return p.expected
+}
+
+// Actual returns the type of the given value.
+func (p ParameterTypeError) Actual() string {
+	return p.actual
+}
+
+// Code returns the error code.
+func (p ParameterTypeError) Code() string {
+	return errs.CodeParameterType
+}
+
+// Path returns an empty string since parameters have no path.
+func (p ParameterTypeError) Path() string {
+	return ""
+}
+
+// Details returns the parameters of the error.
+func (p ParameterTypeError) Details() map[string]interface{} {
+	return map[string]interface{}{"name": p.name, "expected": p.expected, "actual": p.actual}
+}
+
+// Is reports whether the error belongs to given category.
+func (p ParameterTypeError) Is(target error) bool {
+	return target == errs.ErrParameter || target == errs.ErrType
+}
+
+// UnknownMacroError indicate that a used macro is not registered.
+type UnknownMacroError struct {
+	name string
+}
+
+func (u UnknownMacroError) Error() string {
+	return fmt.Sprintf("unknown macro '@%s'", u.name)
+}
+
+// Name returns the name of the unknown macro.
+func (u UnknownMacroError) Name() string {
+	return u.name
+}
+
+// Code returns the error code.
+func (u UnknownMacroError) Code() string {
+	return errs.CodeUnknownMacro
+}
+
+// Path returns an empty string since macros have no path.
+func (u UnknownMacroError) Path() string {
+	return ""
+}
+
+// Details returns the parameters of the error.
+func (u UnknownMacroError) Details() map[string]interface{} {
+	return map[string]interface{}{"name": u.name}
+}
+
+// Is reports whether the error belongs to given category.
+func (u UnknownMacroError) Is(target error) bool {
+	return target == errs.ErrUnknown
+}
+
+// MacroCycleError indicate that macros reference each other recursively.
+type MacroCycleError struct {
+	chain []string
+}
+
+func (m MacroCycleError) Error() string {
+	return fmt.Sprintf("macro cycle '@%s'", strings.Join(m.chain, "' -> '@"))
+}
+
+// Chain returns the names of the macros that form the cycle.
+func (m MacroCycleError) Chain() []string {
+	return append([]string{}, m.chain...)
+}
+
+// Code returns the error code.
+func (m MacroCycleError) Code() string {
+	return errs.CodeMacroCycle
+}
+
+// Path returns an empty string since macros have no path.
+func (m MacroCycleError) Path() string {
+	return ""
+}
+
+// Details returns the parameters of the error.
+func (m MacroCycleError) Details() map[string]interface{} {
+	return map[string]interface{}{"chain": m.Chain()}
+}
+
+// Is reports whether the error belongs to given category.
+func (m MacroCycleError) Is(target error) bool {
+	return target == errs.ErrSyntax
+}
+
+// MacroDepthError indicate that macros are nested too deep.
+type MacroDepthError struct {
+	limit int
+}
+
+func (m MacroDepthError) Error() string {
+	return fmt.Sprintf("macro expansion exceeds depth limit of %d", m.limit)
+}
+
+// Limit returns the exceeded depth limit.
+func (m MacroDepthError) Limit() int {
+	return m.limit
+}
+
+// Code returns the error code.
+func (m MacroDepthError) Code() string {
+	return errs.CodeMacroDepth
+}
+
+// Path returns an empty string since macros have no path.
+func (m MacroDepthError) Path() string {
+	return ""
+}
+
+// Details returns the parameters of the error.
+func (m MacroDepthError) Details() map[string]interface{} {
+	return map[string]interface{}{"limit": m.limit}
+}
+
+// Is reports whether the error belongs to given category.
+func (m MacroDepthError) Is(target error) bool {
+	return target == errs.ErrSyntax
+}
+
+// TransformError indicate that a transformer rejected a literal.
+type TransformError struct {
+	err   error
+	value interface{}
+	field string
+}
+
+func (t TransformError) Error() string {
+	return fmt.Sprintf("value '%v' of '%s' rejected: %s", t.value, t.field, t.err)
+}
+
+// Unwrap returns the error of the transformer.
+func (t TransformError) Unwrap() error {
+	return t.err
+}
+
+// Path returns the field of the rejected value.
+func (t TransformError) Path() string {
+	return t.field
+}
+
+// Value returns the rejected value.
+func (t TransformError) Value() interface{} {
+	return t.value
+}
+
+// Code returns the error code.
+func (t TransformError) Code() string {
+	return errs.CodeValueRejected
+}
+
+// Details returns the parameters of the error.
+func (t TransformError) Details() map[string]interface{} {
+	return map[string]interface{}{"field": t.field, "value": t.value, "reason": t.err.Error()}
+}
+
+// Is reports whether the error belongs to given category.
+func (t TransformError) Is(target error) bool {
+	return target == errs.ErrConstraint
+}
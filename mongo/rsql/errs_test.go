@@ -0,0 +1,105 @@
+package rsql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvalidLiteralError(t *testing.T) {
+	t.Parallel()
+
+	errReason := errors.New("value out of range") //nolint:goerr113
+	err := InvalidLiteralError{Diagnostic: errs.NewDiagnostic("a==9", 3, 1, "9"), kind: "int", err: errReason}
+
+	require.Equal(t, "invalid int literal '9': value out of range", err.Error())
+	require.Equal(t, "int", err.Kind())
+	require.ErrorIs(t, err, errReason)
+	require.Equal(t, map[string]interface{}{"kind": "int", "actual": "9", "position": 3}, err.Details())
+}
+
+func TestUnboundParameterError(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "parameter ':tenant' is not bound",
+		UnboundParameterError{name: "tenant"}.Error())
+}
+
+func TestParameterTypeError(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "parameter ':age' has invalid type 'string', must be number",
+		ParameterTypeError{name: "age", expected: "number", actual: "string"}.Error())
+}
+
+func TestUnknownMacroError(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "unknown macro '@active'",
+		UnknownMacroError{name: "active"}.Error())
+}
+
+func TestMacroCycleError(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "macro cycle '@a' -> '@b' -> '@a'",
+		MacroCycleError{chain: []string{"a", "b", "a"}}.Error())
+}
+
+func TestMacroDepthError(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "macro expansion exceeds depth limit of 3",
+		MacroDepthError{limit: 3}.Error())
+}
+
+func TestTransformError(t *testing.T) {
+	t.Parallel()
+
+	errReason := errors.New("invalid email") //nolint:goerr113
+	err := TransformError{field: "email", value: "x", err: errReason}
+
+	require.Equal(t, "value 'x' of 'email' rejected: invalid email", err.Error())
+	require.ErrorIs(t, err, errReason)
+	require.Equal(t, "email", err.Path())
+}
+
+func TestErrorCategories(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		err      errs.Error
+		category error
+		code     string
+	}{
+		{InvalidLiteralError{kind: "int"}, errs.ErrSyntax, errs.CodeInvalidLiteral},
+		{UnboundParameterError{name: "tenant"}, errs.ErrParameter, errs.CodeUnboundParameter},
+		{ParameterTypeError{name: "age"}, errs.ErrType, errs.CodeParameterType},
+		{UnknownMacroError{name: "active"}, errs.ErrUnknown, errs.CodeUnknownMacro},
+		{MacroCycleError{chain: []string{"a", "a"}}, errs.ErrSyntax, errs.CodeMacroCycle},
+		{MacroDepthError{limit: 3}, errs.ErrSyntax, errs.CodeMacroDepth},
+		{ErrInvalidMacroName, errs.ErrSyntax, errs.CodeInvalidConfiguration},
+		{ErrDuplicateMacro, errs.ErrConstraint, errs.CodeInvalidConfiguration},
+		{ErrEmptyMacro, errs.ErrSyntax, errs.CodeInvalidConfiguration},
+		{ErrNilTransformer, errs.ErrType, errs.CodeNilReference},
+		{TransformError{field: "email", err: errors.New("test")}, errs.ErrConstraint, errs.CodeValueRejected}, //nolint:goerr113
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.code, testCase.err.Code())
+		require.ErrorIs(t, testCase.err, testCase.category)
+		require.NotErrorIs(t, testCase.err, errs.ErrPolicy)
+	}
+
+	require.Equal(t, map[string]interface{}{"name": "age", "expected": "number", "actual": "string"},
+		ParameterTypeError{name: "age", expected: "number", actual: "string"}.Details())
+}
+
+func TestAlwaysFalseError(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, errs.CodeAlwaysFalse, ErrAlwaysFalse.Code())
+	require.True(t, errors.Is(ErrAlwaysFalse, errs.ErrConstraint))
+}
@@ -0,0 +1,100 @@
+package rsql
+
+import (
+	"regexp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// macroNameExpression specifies valid macro names.
+//
+//nolint:gochecknoglobals
+var macroNameExpression = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// RegisterMacro registers a named rsql fragment that can be used as `@name` inside queries.
+// Fragments may use other macros and are expanded while parsing,
+// so policies apply to the fields of the fragment as well.
+func (p *Parser) RegisterMacro(name, expression string) error {
+	if !macroNameExpression.MatchString(name) {
+		return ErrInvalidMacroName
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if _, exists := p.macros[name]; exists {
+		return ErrDuplicateMacro
+	}
+
+	if strings.TrimSpace(expression) == "" {
+		return ErrEmptyMacro
+	}
+
+	p.macros[name] = expression
+
+	return nil
+}
+
+// SetMacroDepthLimit sets the limit for nested macro expansions.
+func (p *Parser) SetMacroDepthLimit(limit int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.macroDepthLimit = limit
+}
+
+/*
+ * <macro>
+ *   : "@" <NAME>
+ * .
+ */
+func (p *parseState) macro() (*bson.E, error) {
+	token, err := p.eat(MacroType)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimPrefix(token.Value, "@")
+
+	expression, exists := p.macros[name]
+	if !exists {
+		return nil, UnknownMacroError{name: name}
+	}
+
+	for _, expanding := range p.expanding {
+		if expanding == name {
+			return nil, MacroCycleError{chain: append(append([]string{}, p.expanding...), name)}
+		}
+	}
+
+	if len(p.expanding) >= p.macroDepthLimit {
+		return nil, MacroDepthError{limit: p.macroDepthLimit}
+	}
+
+	outerTokenizer, outerLookahead := p.tokenizer, p.lookahead
+	p.expanding = append(p.expanding, name)
+
+	defer func() {
+		p.tokenizer, p.lookahead = outerTokenizer, outerLookahead
+		p.expanding = p.expanding[:len(p.expanding)-1]
+	}()
+
+	p.tokenizer = p.newTokenizer(expression)
+
+	err = p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	expanded, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.lookahead != nil {
+		return nil, p.tokenizer.Unexpected(p.lookahead, AndCompositeType, OrCompositeType)
+	}
+
+	return &expanded[0], nil
+}
@@ -0,0 +1,141 @@
+//nolint:funlen
+package rsql
+
+import (
+	"testing"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+	testutil "github.com/StevenCyb/go-mongo-tools/mongo/test_util"
+	"github.com/StevenCyb/go-mongo-tools/tokenizer"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestRegisterMacro(t *testing.T) {
+	t.Parallel()
+
+	parser := NewParser(nil)
+
+	require.NoError(t, parser.RegisterMacro("active", `status=="active"`))
+	require.Equal(t, ErrDuplicateMacro, parser.RegisterMacro("active", `status=="active"`))
+	require.Equal(t, ErrInvalidMacroName, parser.RegisterMacro("in-active", `status=="inactive"`))
+	require.Equal(t, ErrEmptyMacro, parser.RegisterMacro("empty", ` `))
+}
+
+func TestQueryParsingWithMacros(t *testing.T) {
+	t.Parallel()
+
+	newParser := func(policy *tokenizer.Policy) *Parser {
+		parser := NewParser(policy)
+		require.NoError(t, parser.RegisterMacro("active", `status=="active"`))
+		require.NoError(t, parser.RegisterMacro("overdue", `@active;due=lt=100`))
+		require.NoError(t, parser.RegisterMacro("either", `a==1,b==1`))
+		require.NoError(t, parser.RegisterMacro("self", `a==1,@self`))
+		require.NoError(t, parser.RegisterMacro("ping", `@pong`))
+		require.NoError(t, parser.RegisterMacro("pong", `@ping`))
+		require.NoError(t, parser.RegisterMacro("unbalanced", `a==1)`))
+
+		return parser
+	}
+
+	t.Run("SingleMacro_Success", func(t *testing.T) {
+		t.Parallel()
+
+		testutil.ExecuteSuccessTest(t,
+			newParser(nil),
+			`@active;owner=="me"`,
+			bson.D{bson.E{Key: "$and", Value: bson.A{
+				bson.D{bson.E{Key: "status", Value: "active"}},
+				bson.D{bson.E{Key: "owner", Value: "me"}},
+			}}},
+		)
+	})
+
+	t.Run("NestedMacro_Success", func(t *testing.T) {
+		t.Parallel()
+
+		testutil.ExecuteSuccessTest(t,
+			newParser(nil),
+			`@overdue`,
+			bson.D{bson.E{Key: "$and", Value: bson.A{
+				bson.D{bson.E{Key: "status", Value: "active"}},
+				bson.D{bson.E{Key: "due", Value: bson.D{bson.E{Key: "$lt", Value: int64(100)}}}},
+			}}},
+		)
+	})
+
+	t.Run("MacroIsAtomic_Success", func(t *testing.T) {
+		t.Parallel()
+
+		testutil.ExecuteSuccessTest(t,
+			newParser(nil),
+			`@either;c==1`,
+			bson.D{bson.E{Key: "$and", Value: bson.A{
+				bson.D{bson.E{Key: "$or", Value: bson.A{
+					bson.D{bson.E{Key: "a", Value: int64(1)}},
+					bson.D{bson.E{Key: "b", Value: int64(1)}},
+				}}},
+				bson.D{bson.E{Key: "c", Value: int64(1)}},
+			}}},
+		)
+	})
+
+	t.Run("UnknownMacro_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		testutil.ExecuteFailedTest(t,
+			newParser(nil),
+			`@unknown`,
+			UnknownMacroError{name: "unknown"},
+		)
+	})
+
+	t.Run("Cycle_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		testutil.ExecuteFailedTest(t,
+			newParser(nil),
+			`@self`,
+			MacroCycleError{chain: []string{"self", "self"}},
+		)
+		testutil.ExecuteFailedTest(t,
+			newParser(nil),
+			`x==1;@ping`,
+			MacroCycleError{chain: []string{"ping", "pong", "ping"}},
+		)
+	})
+
+	t.Run("DepthLimit_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		parser := newParser(nil)
+		parser.SetMacroDepthLimit(1)
+
+		testutil.ExecuteFailedTest(t,
+			parser,
+			`@overdue`,
+			MacroDepthError{limit: 1},
+		)
+	})
+
+	t.Run("UnbalancedMacro_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		testutil.ExecuteFailedTest(t,
+			newParser(nil),
+			`@unbalanced`,
+			errs.NewErrUnexpectedTokenTypeWithDiagnostic(errs.NewDiagnostic(
+				"a==1)", 4, 1, ")", ";", ",")),
+		)
+	})
+
+	t.Run("PolicyOnExpandedFields_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		testutil.ExecuteFailedTest(t,
+			newParser(tokenizer.NewPolicy(tokenizer.WhitelistPolicy, "owner", "due")),
+			`owner=="me";@overdue`,
+			errs.NewErrPolicyViolationWithDiagnostic(errs.NewDiagnostic(`status=="active"`, 0, 6, "status")),
+		)
+	})
+}
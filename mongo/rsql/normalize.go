@@ -0,0 +1,722 @@
+package rsql
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrAlwaysFalse indicates that a filter can never match any document.
+var ErrAlwaysFalse = errs.NewErrSentinel(errs.ErrConstraint, errs.CodeAlwaysFalse, "filter can never match")
+
+// Normalize simplifies a filter produced by the parser into a canonical form.
+// Nested `$and`/`$or` are flattened, duplicates removed, equalities on the same
+// field inside `$or` merged into `$in` and comparisons on the same field inside
+// `$and` merged into a single range document. Children and `$in` values are
+// ordered, so equivalent filters result in equal output e.g. for cache keys.
+// Since on arrays each condition may match another element, comparisons are only
+// merged into equalities or intersected for given fields that are declared scalar.
+// Contradictions on those fields like `a=gt=5;a=lt=3` return `ErrAlwaysFalse`.
+func Normalize(filter bson.D, scalars ...string) (bson.D, error) {
+	n := normalizer{scalars: map[string]bool{}}
+	for _, field := range scalars {
+		n.scalars[field] = true
+	}
+
+	element, alwaysFalse := n.normalizeDocument(filter)
+	if alwaysFalse {
+		return nil, ErrAlwaysFalse
+	}
+
+	if element == nil {
+		return bson.D{}, nil
+	}
+
+	return bson.D{*element}, nil
+}
+
+// normalizer holds the fields that are declared scalar.
+type normalizer struct {
+	scalars map[string]bool
+}
+
+// normalizeDocument normalizes a document whose elements are implicitly combined by AND.
+func (n normalizer) normalizeDocument(document bson.D) (*bson.E, bool) {
+	elements := make([]bson.E, 0, len(document))
+	elements = append(elements, document...)
+
+	return n.normalizeAnd(elements)
+}
+
+// normalizeElement normalizes a single filter element.
+// A nil element without `alwaysFalse` matches everything.
+func (n normalizer) normalizeElement(element bson.E) (*bson.E, bool) {
+	switch element.Key {
+	case "$and":
+		children, ok, alwaysFalse := n.childElements(element.Value)
+		if !ok {
+			return &element, false
+		}
+
+		if alwaysFalse {
+			return nil, true
+		}
+
+		return n.normalizeAnd(children)
+	case "$or":
+		children, ok := toDocuments(element.Value)
+		if !ok {
+			return &element, false
+		}
+
+		return n.normalizeOr(children)
+	}
+
+	if operator, ok := element.Value.(bson.E); ok {
+		element.Value = bson.D{operator}
+	}
+
+	return &element, false
+}
+
+// normalizeAnd flattens, merges and deduplicates AND combined elements.
+func (n normalizer) normalizeAnd(elements []bson.E) (*bson.E, bool) { //nolint:cyclop
+	var (
+		flat        = []bson.E{}
+		constraints = map[string]*fieldConstraint{}
+		fields      = []string{}
+		others      = []bson.E{}
+	)
+
+	for _, element := range elements {
+		normalized, alwaysFalse := n.normalizeElement(element)
+		if alwaysFalse {
+			return nil, true
+		}
+
+		if normalized == nil {
+			continue
+		}
+
+		if normalized.Key == "$and" {
+			children, _, _ := n.childElements(normalized.Value)
+			flat = append(flat, children...)
+
+			continue
+		}
+
+		flat = append(flat, *normalized)
+	}
+
+	for _, element := range flat {
+		if strings.HasPrefix(element.Key, "$") {
+			others = append(others, element)
+
+			continue
+		}
+
+		constraint, exists := constraints[element.Key]
+		if !exists {
+			constraint = &fieldConstraint{scalar: n.scalars[element.Key]}
+		}
+
+		if !constraint.add(element.Value) {
+			others = append(others, element)
+
+			continue
+		}
+
+		if !exists {
+			constraints[element.Key] = constraint
+			fields = append(fields, element.Key)
+		}
+	}
+
+	result := make([]bson.E, 0, len(fields)+len(others))
+
+	for _, field := range fields {
+		element, alwaysFalse := constraints[field].build(field)
+		if alwaysFalse {
+			return nil, true
+		}
+
+		result = append(result, element)
+	}
+
+	return combine("$and", append(result, others...)), false
+}
+
+// normalizeOr flattens, merges and deduplicates OR combined documents.
+func (n normalizer) normalizeOr(documents []bson.D) (*bson.E, bool) {
+	var (
+		flat   = []bson.E{}
+		values = map[string]bson.A{}
+		fields = []string{}
+		others = []bson.E{}
+	)
+
+	for _, document := range documents {
+		normalized, alwaysFalse := n.normalizeDocument(document)
+		if alwaysFalse {
+			continue
+		}
+
+		if normalized == nil {
+			return nil, false
+		}
+
+		if normalized.Key == "$or" {
+			children, _, _ := n.childElements(normalized.Value)
+			flat = append(flat, children...)
+
+			continue
+		}
+
+		flat = append(flat, *normalized)
+	}
+
+	if len(flat) == 0 {
+		return nil, true
+	}
+
+	for _, element := range flat {
+		candidates, ok := equalityValues(element)
+		if !ok {
+			others = append(others, element)
+
+			continue
+		}
+
+		if _, exists := values[element.Key]; !exists {
+			fields = append(fields, element.Key)
+		}
+
+		values[element.Key] = appendUnique(values[element.Key], candidates...)
+	}
+
+	result := make([]bson.E, 0, len(fields)+len(others))
+
+	for _, field := range fields {
+		result = append(result, inElement(field, values[field]))
+	}
+
+	return combine("$or", append(result, others...)), false
+}
+
+// combine deduplicates and orders elements and joins them with given logical operator.
+func combine(operator string, elements []bson.E) *bson.E {
+	unique := map[string]bson.E{}
+	keys := []string{}
+
+	for _, element := range elements {
+		key := canonicalElement(element)
+		if _, exists := unique[key]; exists {
+			continue
+		}
+
+		unique[key] = element
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	switch len(keys) {
+	case 0:
+		return nil
+	case 1:
+		element := unique[keys[0]]
+
+		return &element
+	}
+
+	children := make(bson.A, 0, len(keys))
+	for _, key := range keys {
+		children = append(children, bson.D{unique[key]})
+	}
+
+	return &bson.E{Key: operator, Value: children}
+}
+
+// fieldConstraint collects mergeable comparisons on a single field.
+// Equalities of fields that are not declared scalar are not merged with other comparisons.
+type fieldConstraint struct {
+	scalar         bool
+	equal          interface{}
+	lower          interface{}
+	upper          interface{}
+	in             bson.A
+	notIn          bson.A
+	hasEqual       bool
+	hasLower       bool
+	hasUpper       bool
+	hasIn          bool
+	lowerInclusive bool
+	upperInclusive bool
+	conflict       bool
+}
+
+// add merges given condition value into the constraint and
+// returns false if the value is not mergeable.
+func (f *fieldConstraint) add(value interface{}) bool {
+	if isScalar(value) {
+		return f.apply("$eq", value)
+	}
+
+	operators, ok := value.(bson.D)
+	if !ok || len(operators) == 0 {
+		return false
+	}
+
+	next := *f
+	for _, operator := range operators {
+		if !next.apply(operator.Key, operator.Value) {
+			return false
+		}
+	}
+
+	*f = next
+
+	return true
+}
+
+// apply merges a single operator into the constraint.
+func (f *fieldConstraint) apply(operator string, value interface{}) bool { //nolint:cyclop
+	if !f.scalar && (f.hasEqual || f.hasIn ||
+		((operator == "$eq" || operator == "$in") && (f.hasLower || f.hasUpper || len(f.notIn) > 0))) {
+		return false
+	}
+
+	switch operator {
+	case "$eq":
+		if !isScalar(value) {
+			return false
+		}
+
+		if f.hasEqual && !valuesEqual(f.equal, value) {
+			f.conflict = true
+		}
+
+		f.equal, f.hasEqual = value, true
+	case "$ne":
+		if !isScalar(value) {
+			return false
+		}
+
+		f.notIn = appendUnique(f.notIn, value)
+	case "$gt", "$gte":
+		return f.applyLower(value, operator == "$gte")
+	case "$lt", "$lte":
+		return f.applyUpper(value, operator == "$lte")
+	case "$in":
+		list, ok := scalarList(value)
+		if !ok {
+			return false
+		}
+
+		if f.hasIn {
+			list = intersect(f.in, list)
+		}
+
+		f.in, f.hasIn = list, true
+	case "$nin":
+		list, ok := scalarList(value)
+		if !ok {
+			return false
+		}
+
+		f.notIn = appendUnique(f.notIn, list...)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// applyLower tightens the lower bound.
+func (f *fieldConstraint) applyLower(value interface{}, inclusive bool) bool {
+	if !isScalar(value) {
+		return false
+	}
+
+	if f.hasLower {
+		cmp, ok := compareValues(value, f.lower)
+		if !ok {
+			return false
+		}
+
+		if cmp < 0 || (cmp == 0 && (inclusive || !f.lowerInclusive)) {
+			return true
+		}
+	}
+
+	f.lower, f.lowerInclusive, f.hasLower = value, inclusive, true
+
+	return true
+}
+
+// applyUpper tightens the upper bound.
+func (f *fieldConstraint) applyUpper(value interface{}, inclusive bool) bool {
+	if !isScalar(value) {
+		return false
+	}
+
+	if f.hasUpper {
+		cmp, ok := compareValues(value, f.upper)
+		if !ok {
+			return false
+		}
+
+		if cmp > 0 || (cmp == 0 && (inclusive || !f.upperInclusive)) {
+			return true
+		}
+	}
+
+	f.upper, f.upperInclusive, f.hasUpper = value, inclusive, true
+
+	return true
+}
+
+// satisfies checks if a scalar value fulfills bounds and exclusions.
+func (f *fieldConstraint) satisfies(value interface{}) bool {
+	if f.hasLower {
+		cmp, ok := compareValues(value, f.lower)
+		if !ok || cmp < 0 || (cmp == 0 && !f.lowerInclusive) {
+			return false
+		}
+	}
+
+	if f.hasUpper {
+		cmp, ok := compareValues(value, f.upper)
+		if !ok || cmp > 0 || (cmp == 0 && !f.upperInclusive) {
+			return false
+		}
+	}
+
+	return !contains(f.notIn, value)
+}
+
+// build returns the simplest element for the constraint or `alwaysFalse`.
+func (f *fieldConstraint) build(field string) (bson.E, bool) { //nolint:cyclop
+	if f.conflict {
+		return bson.E{}, true
+	}
+
+	if f.scalar && f.hasLower && f.hasUpper {
+		cmp, ok := compareValues(f.lower, f.upper)
+		if !ok || cmp > 0 || (cmp == 0 && !(f.lowerInclusive && f.upperInclusive)) {
+			return bson.E{}, true
+		}
+
+		if cmp == 0 && !f.hasEqual && !f.hasIn {
+			f.equal, f.hasEqual = f.lower, true
+		}
+	}
+
+	if f.hasEqual {
+		if !f.satisfies(f.equal) || (f.hasIn && !contains(f.in, f.equal)) {
+			return bson.E{}, true
+		}
+
+		return bson.E{Key: field, Value: f.equal}, false
+	}
+
+	if f.hasIn {
+		values := bson.A{}
+
+		for _, value := range f.in {
+			if f.satisfies(value) {
+				values = append(values, value)
+			}
+		}
+
+		if len(values) == 0 {
+			return bson.E{}, true
+		}
+
+		return inElement(field, values), false
+	}
+
+	operators := bson.D{}
+
+	if f.hasLower {
+		operators = append(operators, bson.E{Key: boundOperator("$gt", f.lowerInclusive), Value: f.lower})
+	}
+
+	if f.hasUpper {
+		operators = append(operators, bson.E{Key: boundOperator("$lt", f.upperInclusive), Value: f.upper})
+	}
+
+	switch len(f.notIn) {
+	case 0:
+	case 1:
+		operators = append(operators, bson.E{Key: "$ne", Value: f.notIn[0]})
+	default:
+		operators = append(operators, bson.E{Key: "$nin", Value: sortValues(f.notIn)})
+	}
+
+	return bson.E{Key: field, Value: operators}, false
+}
+
+// boundOperator returns the inclusive or exclusive variant of an operator.
+func boundOperator(operator string, inclusive bool) string {
+	if inclusive {
+		return operator + "e"
+	}
+
+	return operator
+}
+
+// inElement returns an equality for a single value or `$in` for multiple values.
+func inElement(field string, values bson.A) bson.E {
+	if len(values) == 1 {
+		return bson.E{Key: field, Value: values[0]}
+	}
+
+	return bson.E{Key: field, Value: bson.D{bson.E{Key: "$in", Value: sortValues(values)}}}
+}
+
+// equalityValues returns the values of a plain equality or `$in` element.
+func equalityValues(element bson.E) (bson.A, bool) {
+	if strings.HasPrefix(element.Key, "$") {
+		return nil, false
+	}
+
+	if isScalar(element.Value) {
+		return bson.A{element.Value}, true
+	}
+
+	operators, ok := element.Value.(bson.D)
+	if !ok || len(operators) != 1 || operators[0].Key != "$in" {
+		return nil, false
+	}
+
+	return scalarList(operators[0].Value)
+}
+
+// childElements returns all elements of the documents of a logical operator
+// and reports whether a document with multiple elements can never match.
+func (n normalizer) childElements(value interface{}) ([]bson.E, bool, bool) {
+	documents, ok := toDocuments(value)
+	if !ok {
+		return nil, false, false
+	}
+
+	elements := []bson.E{}
+	for _, document := range documents {
+		if len(document) != 1 {
+			element, alwaysFalse := n.normalizeDocument(document)
+			if alwaysFalse {
+				return nil, true, true
+			}
+
+			if element != nil {
+				elements = append(elements, *element)
+			}
+
+			continue
+		}
+
+		elements = append(elements, document[0])
+	}
+
+	return elements, true, false
+}
+
+// toDocuments converts the value of a logical operator into documents.
+func toDocuments(value interface{}) ([]bson.D, bool) {
+	array, ok := value.(bson.A)
+	if !ok {
+		return nil, false
+	}
+
+	documents := make([]bson.D, 0, len(array))
+
+	for _, item := range array {
+		document, ok := item.(bson.D)
+		if !ok {
+			return nil, false
+		}
+
+		documents = append(documents, document)
+	}
+
+	return documents, true
+}
+
+// scalarList converts given value into a list of scalars.
+func scalarList(value interface{}) (bson.A, bool) {
+	array, ok := value.(bson.A)
+	if !ok {
+		return nil, false
+	}
+
+	for _, item := range array {
+		if !isScalar(item) {
+			return nil, false
+		}
+	}
+
+	return appendUnique(nil, array...), true
+}
+
+// isScalar checks if value is a literal that can be compared and merged.
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case string, bool, int, int32, int64, float32, float64, primitive.ObjectID:
+		return true
+	}
+
+	return false
+}
+
+// toFloat converts numeric values to float64.
+func toFloat(value interface{}) (float64, bool) {
+	switch number := value.(type) {
+	case int:
+		return float64(number), true
+	case int32:
+		return float64(number), true
+	case int64:
+		return float64(number), true
+	case float32:
+		return float64(number), true
+	case float64:
+		return number, true
+	}
+
+	return 0, false
+}
+
+// compareValues compares two scalars of the same kind.
+func compareValues(a, b interface{}) (int, bool) {
+	if numberA, ok := toFloat(a); ok {
+		numberB, ok := toFloat(b)
+		if !ok {
+			return 0, false
+		}
+
+		switch {
+		case numberA < numberB:
+			return -1, true
+		case numberA > numberB:
+			return 1, true
+		}
+
+		return 0, true
+	}
+
+	if stringA, ok := a.(string); ok {
+		stringB, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+
+		return strings.Compare(stringA, stringB), true
+	}
+
+	if reflect.TypeOf(a) == reflect.TypeOf(b) && reflect.DeepEqual(a, b) {
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// valuesEqual checks if two scalars are equal.
+func valuesEqual(a, b interface{}) bool {
+	cmp, ok := compareValues(a, b)
+
+	return ok && cmp == 0
+}
+
+// contains checks if list contains value.
+func contains(list bson.A, value interface{}) bool {
+	for _, item := range list {
+		if valuesEqual(item, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// appendUnique appends values that are not already part of the list.
+func appendUnique(list bson.A, values ...interface{}) bson.A {
+	for _, value := range values {
+		if !contains(list, value) {
+			list = append(list, value)
+		}
+	}
+
+	return list
+}
+
+// intersect returns values that are part of both lists.
+func intersect(a, b bson.A) bson.A {
+	result := bson.A{}
+
+	for _, value := range a {
+		if contains(b, value) {
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
+
+// sortValues returns a copy of the values in canonical order.
+func sortValues(values bson.A) bson.A {
+	sorted := make(bson.A, len(values))
+	copy(sorted, values)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return canonicalValue(sorted[i]) < canonicalValue(sorted[j])
+	})
+
+	return sorted
+}
+
+// canonicalElement returns a stable textual representation of an element.
+func canonicalElement(element bson.E) string {
+	return strconv.Quote(element.Key) + ":" + canonicalValue(element.Value)
+}
+
+// canonicalValue returns a stable textual representation of a value.
+func canonicalValue(value interface{}) string {
+	switch typed := value.(type) {
+	case bson.D:
+		parts := make([]string, 0, len(typed))
+		for _, element := range typed {
+			parts = append(parts, canonicalElement(element))
+		}
+
+		return "{" + strings.Join(parts, ",") + "}"
+	case bson.E:
+		return "{" + canonicalElement(typed) + "}"
+	case bson.A:
+		parts := make([]string, 0, len(typed))
+		for _, item := range typed {
+			parts = append(parts, canonicalValue(item))
+		}
+
+		return "[" + strings.Join(parts, ",") + "]"
+	case string:
+		return strconv.Quote(typed)
+	case primitive.ObjectID:
+		return "ObjectId(" + typed.Hex() + ")"
+	case regexp.Regexp:
+		return "/" + typed.String() + "/"
+	case *regexp.Regexp:
+		return "/" + typed.String() + "/"
+	case nil:
+		return "null"
+	}
+
+	return fmt.Sprintf("%v", value)
+}
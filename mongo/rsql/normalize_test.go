@@ -0,0 +1,200 @@
+//nolint:funlen
+package rsql
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// scalars are the fields that are declared scalar in the tests.
+//
+//nolint:gochecknoglobals
+var scalars = []string{"a", "b", "c", "d"}
+
+func executeNormalizeTest(t *testing.T, query string, expect bson.D) {
+	t.Helper()
+
+	filter, err := NewParser(nil).Parse(query)
+	require.NoError(t, err)
+
+	normalized, err := Normalize(filter, scalars...)
+	require.NoError(t, err)
+	require.Equal(t, expect, normalized)
+}
+
+func executeAlwaysFalseTest(t *testing.T, query string) {
+	t.Helper()
+
+	filter, err := NewParser(nil).Parse(query)
+	require.NoError(t, err)
+
+	_, err = Normalize(filter, scalars...)
+	require.ErrorIs(t, err, ErrAlwaysFalse)
+}
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	t.Run("EmptyFilter_Success", func(t *testing.T) {
+		t.Parallel()
+
+		normalized, err := Normalize(bson.D{})
+		require.NoError(t, err)
+		require.Equal(t, bson.D{}, normalized)
+	})
+
+	t.Run("SingleComparison_Success", func(t *testing.T) {
+		t.Parallel()
+
+		executeNormalizeTest(t, `a==1`, bson.D{bson.E{Key: "a", Value: int64(1)}})
+	})
+
+	t.Run("FlattenNestedAnd_Success", func(t *testing.T) {
+		t.Parallel()
+
+		executeNormalizeTest(t, `(a==1;(b==2;c==3));d==4`, bson.D{
+			bson.E{Key: "$and", Value: bson.A{
+				bson.D{bson.E{Key: "a", Value: int64(1)}},
+				bson.D{bson.E{Key: "b", Value: int64(2)}},
+				bson.D{bson.E{Key: "c", Value: int64(3)}},
+				bson.D{bson.E{Key: "d", Value: int64(4)}},
+			}},
+		})
+	})
+
+	t.Run("Deduplicate_Success", func(t *testing.T) {
+		t.Parallel()
+
+		executeNormalizeTest(t, `a==1;a==1`, bson.D{bson.E{Key: "a", Value: int64(1)}})
+		executeNormalizeTest(t, `b=sw="x",b=sw="x"`, bson.D{bson.E{Key: "b", Value: *regexp.MustCompile("^x")}})
+	})
+
+	t.Run("MergeEqualityIntoIn_Success", func(t *testing.T) {
+		t.Parallel()
+
+		executeNormalizeTest(t, `a==1,a==2,a=in=(2,3)`, bson.D{
+			bson.E{Key: "a", Value: bson.D{
+				bson.E{Key: "$in", Value: bson.A{int64(1), int64(2), int64(3)}},
+			}},
+		})
+	})
+
+	t.Run("MergeRange_Success", func(t *testing.T) {
+		t.Parallel()
+
+		executeNormalizeTest(t, `a=gt=1;a=ge=3;a=lt=10;a=le=8`, bson.D{
+			bson.E{Key: "a", Value: bson.D{
+				bson.E{Key: "$gte", Value: int64(3)},
+				bson.E{Key: "$lte", Value: int64(8)},
+			}},
+		})
+	})
+
+	t.Run("MergeRangeIntoEquality_Success", func(t *testing.T) {
+		t.Parallel()
+
+		executeNormalizeTest(t, `a=ge=5;a=le=5`, bson.D{bson.E{Key: "a", Value: int64(5)}})
+		executeNormalizeTest(t, `a==5;a=gt=1`, bson.D{bson.E{Key: "a", Value: int64(5)}})
+	})
+
+	t.Run("MergeInWithRange_Success", func(t *testing.T) {
+		t.Parallel()
+
+		executeNormalizeTest(t, `a=in=(1,2,3,4);a=gt=1;a!=3`, bson.D{
+			bson.E{Key: "a", Value: bson.D{
+				bson.E{Key: "$in", Value: bson.A{int64(2), int64(4)}},
+			}},
+		})
+	})
+
+	t.Run("MergeNotEqual_Success", func(t *testing.T) {
+		t.Parallel()
+
+		executeNormalizeTest(t, `a!=1;a!=2`, bson.D{
+			bson.E{Key: "a", Value: bson.D{
+				bson.E{Key: "$nin", Value: bson.A{int64(1), int64(2)}},
+			}},
+		})
+	})
+
+	t.Run("CanonicalOrder_Success", func(t *testing.T) {
+		t.Parallel()
+
+		first, err := NewParser(nil).Parse(`b==2;(a==1,c==3)`)
+		require.NoError(t, err)
+		second, err := NewParser(nil).Parse(`(c==3,a==1);b==2`)
+		require.NoError(t, err)
+
+		first, err = Normalize(first, scalars...)
+		require.NoError(t, err)
+		second, err = Normalize(second, scalars...)
+		require.NoError(t, err)
+		require.Equal(t, first, second)
+	})
+
+	t.Run("DropAlwaysFalseOrBranch_Success", func(t *testing.T) {
+		t.Parallel()
+
+		executeNormalizeTest(t, `(a=gt=5;a=lt=3),b==1`, bson.D{bson.E{Key: "b", Value: int64(1)}})
+	})
+
+	t.Run("AlwaysFalse_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		executeAlwaysFalseTest(t, `a=gt=5;a=lt=3`)
+		executeAlwaysFalseTest(t, `a=gt=5;a=le=5`)
+		executeAlwaysFalseTest(t, `a==1;a==2`)
+		executeAlwaysFalseTest(t, `a==1;a!=1`)
+		executeAlwaysFalseTest(t, `a=in=(1,2);a=out=(1,2)`)
+		executeAlwaysFalseTest(t, `(a==1;a==2),(b==1;b==2)`)
+	})
+
+	t.Run("AlwaysFalseChildDocument_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Normalize(bson.D{{Key: "$and", Value: bson.A{
+			bson.D{
+				{Key: "a", Value: bson.D{{Key: "$gt", Value: 5}, {Key: "$lt", Value: 3}}},
+				{Key: "b", Value: 1},
+			},
+		}}}, scalars...)
+		require.ErrorIs(t, err, ErrAlwaysFalse)
+
+		filter, err := NewScopedParser(nil, bson.D{
+			{Key: "tenant", Value: "t1"},
+			{Key: "a", Value: bson.D{{Key: "$gte", Value: 5}, {Key: "$lte", Value: 3}}},
+		}).Parse(`b==2`)
+		require.NoError(t, err)
+
+		_, err = Normalize(filter, scalars...)
+		require.ErrorIs(t, err, ErrAlwaysFalse)
+	})
+
+	t.Run("ArrayFields_Success", func(t *testing.T) {
+		t.Parallel()
+
+		filter, err := NewParser(nil).Parse(`tags=="a";tags=="b";tags=gt=5;tags=lt=3;tags=in=(1,2);tags=in=(3,4)`)
+		require.NoError(t, err)
+
+		normalized, err := Normalize(filter)
+		require.NoError(t, err)
+		require.Equal(t, bson.D{bson.E{Key: "$and", Value: bson.A{
+			bson.D{bson.E{Key: "tags", Value: "a"}},
+			bson.D{bson.E{Key: "tags", Value: "b"}},
+			bson.D{bson.E{Key: "tags", Value: bson.D{bson.E{Key: "$gt", Value: int64(5)}}}},
+			bson.D{bson.E{Key: "tags", Value: bson.D{bson.E{Key: "$in", Value: bson.A{int64(1), int64(2)}}}}},
+			bson.D{bson.E{Key: "tags", Value: bson.D{bson.E{Key: "$in", Value: bson.A{int64(3), int64(4)}}}}},
+			bson.D{bson.E{Key: "tags", Value: bson.D{bson.E{Key: "$lt", Value: int64(3)}}}},
+		}}}, normalized)
+
+		executeNormalizeTest(t, `tags=gt=1;tags=gt=3;tags=le=5`, bson.D{
+			bson.E{Key: "tags", Value: bson.D{
+				bson.E{Key: "$gt", Value: int64(3)},
+				bson.E{Key: "$lte", Value: int64(5)},
+			}},
+		})
+	})
+}
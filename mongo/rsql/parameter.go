@@ -0,0 +1,150 @@
+package rsql
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// parameterKind specifies which kind of parameter value is expected.
+type parameterKind byte
+
+const (
+	numericParameter parameterKind = 1 << iota
+	stringParameter
+	scalarParameter
+	listParameter
+)
+
+// String return the kinds as readable text.
+func (k parameterKind) String() string {
+	names := []string{}
+
+	if k&numericParameter != 0 {
+		names = append(names, "number")
+	}
+
+	if k&stringParameter != 0 {
+		names = append(names, "string")
+	}
+
+	if k&scalarParameter != 0 {
+		names = append(names, "scalar")
+	}
+
+	if k&listParameter != 0 {
+		names = append(names, "list")
+	}
+
+	return strings.Join(names, " or ")
+}
+
+/*
+ * <placeholder>
+ * : ":" <NAME>
+ * .
+ */
+func (p *parseState) placeholder(kind parameterKind) (interface{}, error) {
+	token, err := p.eat(PlaceholderType)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimPrefix(token.Value, ":")
+
+	value, bound := p.parameters[name]
+	if !bound {
+		return nil, UnboundParameterError{name: name}
+	}
+
+	if kind&listParameter != 0 {
+		if list, ok := parameterList(value); ok {
+			return list, nil
+		}
+	}
+
+	scalar, ok := parameterScalar(value)
+	if ok {
+		_, isString := scalar.(string)
+		_, isNumber := toFloat(scalar)
+
+		switch {
+		case kind&scalarParameter != 0,
+			kind&stringParameter != 0 && isString,
+			kind&numericParameter != 0 && isNumber:
+			return scalar, nil
+		}
+	}
+
+	return nil, ParameterTypeError{name: name, expected: kind.String(), actual: fmt.Sprintf("%T", value)}
+}
+
+// listPlaceholder binds a placeholder that must be a list.
+func (p *parseState) listPlaceholder() (bson.A, error) {
+	value, err := p.placeholder(listParameter)
+	if err != nil {
+		return nil, err
+	}
+
+	return value.(bson.A), nil //nolint:forcetypeassert
+}
+
+// parameterScalar converts a parameter value into a literal as produced by the parser.
+func parameterScalar(value interface{}) (interface{}, bool) {
+	switch typed := value.(type) {
+	case string, bool, int64, float64, primitive.ObjectID:
+		return typed, true
+	case int:
+		return int64(typed), true
+	case int8:
+		return int64(typed), true
+	case int16:
+		return int64(typed), true
+	case int32:
+		return int64(typed), true
+	case uint:
+		return int64(typed), true
+	case uint8:
+		return int64(typed), true
+	case uint16:
+		return int64(typed), true
+	case uint32:
+		return int64(typed), true
+	case float32:
+		return float64(typed), true
+	}
+
+	return nil, false
+}
+
+// parameterList converts a slice or array parameter into a literal list.
+func parameterList(value interface{}) (bson.A, bool) {
+	if value == nil {
+		return nil, false
+	}
+
+	reflectValue := reflect.ValueOf(value)
+	if reflectValue.Kind() != reflect.Slice && reflectValue.Kind() != reflect.Array {
+		return nil, false
+	}
+
+	if _, isObjectID := value.(primitive.ObjectID); isObjectID {
+		return nil, false
+	}
+
+	list := make(bson.A, 0, reflectValue.Len())
+
+	for i := 0; i < reflectValue.Len(); i++ {
+		item, ok := parameterScalar(reflectValue.Index(i).Interface())
+		if !ok {
+			return nil, false
+		}
+
+		list = append(list, item)
+	}
+
+	return list, true
+}
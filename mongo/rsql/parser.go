@@ -1,10 +1,12 @@
 package rsql
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/StevenCyb/go-mongo-tools/errs"
 	"github.com/StevenCyb/go-mongo-tools/tokenizer"
@@ -29,12 +31,24 @@ const (
 	OidLiteralType                       tokenizer.Type = "OID_LITERAL"
 	FieldNameType                        tokenizer.Type = "FIELD_NAME"
 	NumberLiteralType                    tokenizer.Type = "NUMERIC_LITERAL"
+	PlaceholderType                      tokenizer.Type = "PLACEHOLDER"
+	MacroType                            tokenizer.Type = "MACRO"
+
+	// DefaultMacroDepthLimit is the default limit for nested macro expansions.
+	DefaultMacroDepthLimit = 8
 
 	intBase     = 10
 	int64Size   = 64
 	float64Size = 64
 )
 
+// literalTypes are the token types of literals.
+//
+//nolint:gochecknoglobals
+var literalTypes = []tokenizer.Type{
+	OidLiteralType, BoolLiteralType, QuotedStringLiteralType, NumberLiteralType, PlaceholderType,
+}
+
 // specialEncode is the map for encoding
 // a list of special characters.
 //
@@ -50,46 +64,86 @@ var specialEncode = map[string]string{
 }
 
 // NewParser creates a new parser.
-func NewParser(policy *tokenizer.Policy) *Parser {
+func NewParser(policy tokenizer.FieldPolicy) *Parser {
 	return &Parser{
-		policy: policy,
+		policy:          policy,
+		macros:          map[string]string{},
+		transformers:    map[string][]Transformer{},
+		macroDepthLimit: DefaultMacroDepthLimit,
 	}
 }
 
 // Parser provides the logic to parse
-// rsql statements.
+// rsql statements. Macros and transformers may be registered
+// while other goroutines parse, they are guarded by a read-write mutex.
 type Parser struct {
-	tokenizer *tokenizer.Tokenizer
-	lookahead *tokenizer.Token
-	policy    *tokenizer.Policy
+	policy          tokenizer.FieldPolicy
+	macros          map[string]string
+	transformers    map[string][]Transformer
+	mutex           sync.RWMutex
+	macroDepthLimit int
+}
+
+// parseState holds the state of a single parsing.
+type parseState struct {
+	*Parser
+	tokenizer  *tokenizer.Tokenizer
+	lookahead  *tokenizer.Token
+	ctx        context.Context //nolint:containedctx
+	parameters map[string]interface{}
+	expanding  []string
 }
 
 // eat return a token with expected type.
-func (p *Parser) eat(tokenType tokenizer.Type) (*tokenizer.Token, error) {
+func (p *parseState) eat(tokenType tokenizer.Type) (*tokenizer.Token, error) {
 	token := p.lookahead
 
-	if token == nil {
-		return nil, errs.NewErrUnexpectedInputEnd(tokenType.String())
+	if token == nil || token.Type != tokenType {
+		return nil, p.tokenizer.Unexpected(p.lookahead, tokenType)
 	}
 
-	if token.Type != tokenType {
-		return nil, errs.NewErrUnexpectedTokenType(
-			p.tokenizer.GetCursorPosition(),
-			token.Type.String(),
-			tokenType.String(),
-		)
-	}
+	return token, p.next()
+}
 
+// next fetches the next token as lookahead.
+func (p *parseState) next() error {
 	var err error
+
 	p.lookahead, err = p.tokenizer.GetNextToken()
 
-	return token, err //nolint:wrapcheck
+	return err //nolint:wrapcheck
+}
+
+// lookaheadType returns the type of the lookahead or an empty type at the end of input.
+func (p *parseState) lookaheadType() tokenizer.Type {
+	if p.lookahead == nil {
+		return ""
+	}
+
+	return p.lookahead.Type
 }
 
 // Parse a given query.
 func (p *Parser) Parse(query string) (bson.D, error) {
-	var err error
+	return p.ParseWithParametersContext(context.Background(), query, nil)
+}
 
+// ParseContext parses a given query and passes the context to the policy.
+func (p *Parser) ParseContext(ctx context.Context, query string) (bson.D, error) {
+	return p.ParseWithParametersContext(ctx, query, nil)
+}
+
+// ParseWithParameters parses a given query and binds
+// placeholders like `:name` to the given parameters.
+// Parameter values are used as they are and never tokenized.
+func (p *Parser) ParseWithParameters(query string, parameters map[string]interface{}) (bson.D, error) {
+	return p.ParseWithParametersContext(context.Background(), query, parameters)
+}
+
+// ParseWithParametersContext parses a given query with parameters and passes the context to the policy.
+func (p *Parser) ParseWithParametersContext(
+	ctx context.Context, query string, parameters map[string]interface{},
+) (bson.D, error) {
 	if query == "" {
 		return bson.D{}, nil
 	}
@@ -98,52 +152,48 @@ func (p *Parser) Parse(query string) (bson.D, error) {
 		query = strings.ReplaceAll(query, enc, dec)
 	}
 
-	p.tokenizer = tokenizer.NewTokenizer(
-		query,
-		SkipType, FieldNameType,
-		[]*tokenizer.Spec{
-			tokenizer.NewSpec(`^\s+`, SkipType),
-			tokenizer.NewSpec(`^\(`, ContextStartType),
-			tokenizer.NewSpec(`^\)`, ContextEndType),
-			tokenizer.NewSpec(`^;`, AndCompositeType),
-			tokenizer.NewSpec(`^,`, OrCompositeType),
-			tokenizer.NewSpec(`^(==|!=)`, ValueCompareOperatorType),
-			tokenizer.NewSpec(`^(=sw=|=ew=)`, QuotedStringValueCompareOperatorType),
-			tokenizer.NewSpec(`^(=gt=|=ge=|=lt=|=le=)`, NumericValueCompareOperatorType),
-			tokenizer.NewSpec(`^(=in=|=out=)`, ArrayCompareOperatorType),
-			tokenizer.NewSpec(`^\$oid\([0-9a-fA-F]+\)`, OidLiteralType),
-			tokenizer.NewSpec(`(?i)^(true|false)`, BoolLiteralType),
-			tokenizer.NewSpec(`^(-|\+)?\d+(\.\d+)?`, NumberLiteralType),
-			tokenizer.NewSpec(`^("[^"]*"|'[^']*')`, QuotedStringLiteralType),
-			tokenizer.NewSpec(`^[^!=]*`, FieldNameType),
-		},
-		p.policy,
-	)
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
 
-	p.lookahead, err = p.tokenizer.GetNextToken()
+	state := &parseState{Parser: p, ctx: ctx, parameters: parameters}
+	state.tokenizer = state.newTokenizer(query)
+
+	err := state.next()
 	if err != nil {
-		return nil, err //nolint:wrapcheck
+		return nil, err
 	}
 
-	return p.expression()
+	return state.expression()
+}
+
+// newTokenizer creates a tokenizer for given query with the rsql grammar.
+func (p *parseState) newTokenizer(query string) *tokenizer.Tokenizer {
+	return tokenizer.NewScannerTokenizer(
+		query,
+		SkipType, FieldNameType,
+		scan,
+		p.policy,
+	).WithContext(p.ctx)
 }
 
 /*
  * <expression>
  *   : <context>
  *   | <context> <composite_operator> <expression>
+ *   | <macro>
+ *   | <macro> <composite_operator> <expression>
  *   | <comparison>
  *   | <comparison> <composite_operator> <expression>
  * .
  */
-func (p *Parser) expression() ([]bson.E, error) { //nolint:funlen
+func (p *parseState) expression() ([]bson.E, error) { //nolint:funlen
 	var (
 		left           bson.E
 		sortStatements = []bson.E{}
 	)
 
 	if p.lookahead == nil {
-		return nil, errs.NewErrUnexpectedInputEnd(FieldNameType.String())
+		return nil, p.tokenizer.Unexpected(p.lookahead, FieldNameType, ContextStartType, MacroType)
 	}
 
 	if p.lookahead.Type == ContextStartType {
@@ -153,6 +203,13 @@ func (p *Parser) expression() ([]bson.E, error) { //nolint:funlen
 		}
 
 		left = tmp[0]
+	} else if p.lookahead.Type == MacroType {
+		tmp, err := p.macro()
+		if err != nil {
+			return nil, err
+		}
+
+		left = *tmp
 	} else {
 		tmp, err := p.comparison()
 		if err != nil {
@@ -210,7 +267,7 @@ func (p *Parser) expression() ([]bson.E, error) { //nolint:funlen
  *   : "(" <expression> ")"
  * .
  */
-func (p *Parser) context() ([]bson.E, error) {
+func (p *parseState) context() ([]bson.E, error) {
 	_, err := p.eat(ContextStartType)
 	if err != nil {
 		return nil, err
@@ -235,41 +292,53 @@ func (p *Parser) context() ([]bson.E, error) {
  *   | ","
  * .
  */
-func (p *Parser) compositeOperation() (*tokenizer.Token, error) {
+func (p *parseState) compositeOperation() (*tokenizer.Token, error) {
 	if p.lookahead.Type == AndCompositeType {
 		return p.eat(AndCompositeType)
 	} else if p.lookahead.Type == OrCompositeType {
 		return p.eat(OrCompositeType)
 	}
 
-	return nil, errs.NewErrUnexpectedTokenType(
-		p.tokenizer.GetCursorPosition()-len(p.lookahead.Value),
-		p.lookahead.Type.String(),
-		";/,")
+	return nil, p.tokenizer.Unexpected(p.lookahead, AndCompositeType, OrCompositeType)
 }
 
 /*
  * <array_comparison>
  *   | <plural_operator> "(" <literal_list> ")"
+ *   | <plural_operator> <placeholder>
  * .
  */
-func (p *Parser) arrayComparison(key string) (*bson.E, error) {
+func (p *parseState) arrayComparison(key string) (*bson.E, error) {
 	operator, err := p.eat(ArrayCompareOperatorType)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = p.eat(ContextStartType)
-	if err != nil {
-		return nil, err
-	}
+	var literalList bson.A
+	//nolint:nestif
+	if p.lookahead != nil && p.lookahead.Type == PlaceholderType {
+		literalList, err = p.listPlaceholder()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		_, err = p.eat(ContextStartType)
+		if err != nil {
+			return nil, err
+		}
 
-	literalList, err := p.literalList()
-	if err != nil {
-		return nil, err
+		literalList, err = p.literalList()
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = p.eat(ContextEndType)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	_, err = p.eat(ContextEndType)
+	literalList, err = p.transformList(key, literalList)
 	if err != nil {
 		return nil, err
 	}
@@ -280,10 +349,7 @@ func (p *Parser) arrayComparison(key string) (*bson.E, error) {
 	case "=out=":
 		return &bson.E{Key: key, Value: bson.E{Key: "$nin", Value: literalList}}, nil
 	default:
-		return nil, errs.NewErrUnexpectedTokenType(
-			p.tokenizer.GetCursorPosition()-len(operator.Value),
-			operator.Type.String(),
-			ArrayCompareOperatorType.String())
+		return nil, p.tokenizer.Unexpected(operator, ArrayCompareOperatorType)
 	}
 }
 
@@ -292,7 +358,7 @@ func (p *Parser) arrayComparison(key string) (*bson.E, error) {
  *   | <singular_string_operator> <quoted_string_literal>
  * .
  */
-func (p *Parser) numericValueComparison(key string) (*bson.E, error) {
+func (p *parseState) numericValueComparison(key string) (*bson.E, error) {
 	operator, err := p.eat(NumericValueCompareOperatorType)
 	if err != nil {
 		return nil, err
@@ -303,6 +369,11 @@ func (p *Parser) numericValueComparison(key string) (*bson.E, error) {
 		return nil, err
 	}
 
+	literal, err = p.transform(key, literal)
+	if err != nil {
+		return nil, err
+	}
+
 	switch operator.Value {
 	case "=gt=":
 		return &bson.E{Key: key, Value: bson.D{bson.E{Key: "$gt", Value: literal}}}, nil
@@ -313,10 +384,7 @@ func (p *Parser) numericValueComparison(key string) (*bson.E, error) {
 	case "=le=":
 		return &bson.E{Key: key, Value: bson.D{bson.E{Key: "$lte", Value: literal}}}, nil
 	default:
-		return nil, errs.NewErrUnexpectedTokenType(
-			p.tokenizer.GetCursorPosition()-len(operator.Value),
-			operator.Type.String(),
-			ValueCompareOperatorType.String())
+		return nil, p.tokenizer.Unexpected(operator, ValueCompareOperatorType)
 	}
 }
 
@@ -325,17 +393,28 @@ func (p *Parser) numericValueComparison(key string) (*bson.E, error) {
  *   | <singular_string_operator> <quoted_string_literal>
  * .
  */
-func (p *Parser) quotedStringComparison(key string) (*bson.E, error) {
+func (p *parseState) quotedStringComparison(key string) (*bson.E, error) {
 	operator, err := p.eat(QuotedStringValueCompareOperatorType)
 	if err != nil {
 		return nil, err
 	}
 
+	escape := p.lookahead != nil && p.lookahead.Type == PlaceholderType
+
 	literal, err := p.stringLiteral()
 	if err != nil {
 		return nil, err
 	}
 
+	literal, err = p.transform(key, literal)
+	if err != nil {
+		return nil, err
+	}
+
+	if escape {
+		literal = regexp.QuoteMeta(fmt.Sprintf("%v", literal))
+	}
+
 	switch operator.Value {
 	case "=sw=":
 		wildcard, err := regexp.Compile("^" + fmt.Sprintf("%v", literal))
@@ -346,10 +425,7 @@ func (p *Parser) quotedStringComparison(key string) (*bson.E, error) {
 
 		return &bson.E{Key: key, Value: *wildcard}, errors.Wrap(err, "failed to create wildcard expression")
 	default:
-		return nil, errs.NewErrUnexpectedTokenType(
-			p.tokenizer.GetCursorPosition()-len(operator.Value),
-			operator.Type.String(),
-			ValueCompareOperatorType.String())
+		return nil, p.tokenizer.Unexpected(operator, ValueCompareOperatorType)
 	}
 }
 
@@ -358,7 +434,7 @@ func (p *Parser) quotedStringComparison(key string) (*bson.E, error) {
  *   : <singular_operator> <literal>
  * .
  */
-func (p *Parser) literalComparison(key string) (*bson.E, error) {
+func (p *parseState) literalComparison(key string) (*bson.E, error) {
 	operator, err := p.eat(ValueCompareOperatorType)
 	if err != nil {
 		return nil, err
@@ -366,7 +442,14 @@ func (p *Parser) literalComparison(key string) (*bson.E, error) {
 
 	var literal interface{}
 	//nolint:nestif
-	if p.lookahead.Type == ContextStartType {
+	if p.lookahead == nil {
+		return nil, p.tokenizer.Unexpected(p.lookahead, append(literalTypes, ContextStartType)...)
+	} else if p.lookahead.Type == PlaceholderType {
+		literal, err = p.placeholder(scalarParameter | listParameter)
+		if err != nil {
+			return nil, err
+		}
+	} else if p.lookahead.Type == ContextStartType {
 		_, err = p.eat(ContextStartType)
 		if err != nil {
 			return nil, err
@@ -388,16 +471,18 @@ func (p *Parser) literalComparison(key string) (*bson.E, error) {
 		}
 	}
 
+	literal, err = p.transform(key, literal)
+	if err != nil {
+		return nil, err
+	}
+
 	switch operator.Value {
 	case "==":
 		return &bson.E{Key: key, Value: literal}, nil
 	case "!=":
 		return &bson.E{Key: key, Value: bson.D{bson.E{Key: "$ne", Value: literal}}}, nil
 	default:
-		return nil, errs.NewErrUnexpectedTokenType(
-			p.tokenizer.GetCursorPosition()-len(operator.Value),
-			operator.Type.String(),
-			ValueCompareOperatorType.String())
+		return nil, p.tokenizer.Unexpected(operator, ValueCompareOperatorType)
 	}
 }
 
@@ -409,19 +494,15 @@ func (p *Parser) literalComparison(key string) (*bson.E, error) {
  *   | TEXT <array_comparison>
  * .
  */
-func (p *Parser) comparison() (*bson.E, error) {
+func (p *parseState) comparison() (*bson.E, error) {
 	keyToken, err := p.eat(FieldNameType)
 	if err != nil {
 		return nil, err
 	}
 
-	if p.lookahead == nil {
-		return nil, errs.NewErrUnexpectedInputEnd(FieldNameType.String())
-	}
-
 	key := keyToken.Value
 
-	switch p.lookahead.Type {
+	switch p.lookaheadType() {
 	case ValueCompareOperatorType:
 		return p.literalComparison(key)
 	case QuotedStringValueCompareOperatorType:
@@ -432,9 +513,9 @@ func (p *Parser) comparison() (*bson.E, error) {
 		return p.arrayComparison(key)
 	}
 
-	return nil, errs.NewErrUnexpectedToken(
-		p.tokenizer.GetCursorPosition()-len(p.lookahead.Value),
-		p.lookahead.Value)
+	return nil, p.tokenizer.Unexpected(p.lookahead,
+		ValueCompareOperatorType, QuotedStringValueCompareOperatorType,
+		NumericValueCompareOperatorType, ArrayCompareOperatorType)
 }
 
 /*
@@ -443,9 +524,14 @@ func (p *Parser) comparison() (*bson.E, error) {
  * : <bool_literal>
  * | <quoted_string_literal>
  * | <numeric_literal>
+ * | <placeholder>
  * .
  */
-func (p *Parser) literal() (interface{}, error) {
+func (p *parseState) literal() (interface{}, error) {
+	if p.lookahead == nil {
+		return nil, p.tokenizer.Unexpected(p.lookahead, literalTypes...)
+	}
+
 	switch p.lookahead.Type {
 	case OidLiteralType:
 		token, err := p.eat(OidLiteralType)
@@ -458,7 +544,7 @@ func (p *Parser) literal() (interface{}, error) {
 
 		oid, err := primitive.ObjectIDFromHex(oidHex)
 		if err != nil {
-			return nil, fmt.Errorf("could not parse $oid '%s': %w", oidHex, err)
+			return nil, p.invalidLiteral(token, "$oid", err)
 		}
 
 		return oid, nil
@@ -473,21 +559,30 @@ func (p *Parser) literal() (interface{}, error) {
 		return p.stringLiteral()
 	case NumberLiteralType:
 		return p.numericLiteral()
+	case PlaceholderType:
+		return p.placeholder(scalarParameter)
 	}
 
-	return nil, errs.NewErrUnexpectedTokenType(
-		p.tokenizer.GetCursorPosition()-len(p.lookahead.Value),
-		p.lookahead.Type.String(),
-		"LITERAL")
+	return nil, p.tokenizer.Unexpected(p.lookahead, literalTypes...)
 }
 
 /*
  * <quoted_string_literal>
  * : "'" <TEXT> "'"
  * | """ <TEXT> """
+ * | <placeholder>
  * .
  */
-func (p *Parser) stringLiteral() (interface{}, error) {
+func (p *parseState) stringLiteral() (interface{}, error) {
+	if p.lookahead != nil && p.lookahead.Type == PlaceholderType {
+		value, err := p.placeholder(stringParameter)
+		if err != nil {
+			return nil, err
+		}
+
+		return value, nil
+	}
+
 	token, err := p.eat(QuotedStringLiteralType)
 	if err != nil {
 		return nil, err
@@ -502,25 +597,43 @@ func (p *Parser) stringLiteral() (interface{}, error) {
  * <numeric_literal>
  * : <INT>
  * | <FLOAT>
+ * | <placeholder>
  * .
  */
-func (p *Parser) numericLiteral() (interface{}, error) {
+func (p *parseState) numericLiteral() (interface{}, error) {
+	if p.lookahead != nil && p.lookahead.Type == PlaceholderType {
+		return p.placeholder(numericParameter)
+	}
+
 	token, err := p.eat(NumberLiteralType)
 	if err != nil {
 		return nil, err
 	}
 
 	if strings.Contains(token.Value, ".") {
-		var value float64
-		value, err = strconv.ParseFloat(token.Value, float64Size)
+		value, err := strconv.ParseFloat(token.Value, float64Size)
+		if err != nil {
+			return nil, p.invalidLiteral(token, "float", err)
+		}
+
+		return value, nil
+	}
 
-		return value, errors.Wrap(err, "failed to parse float value")
+	value, err := strconv.ParseInt(token.Value, intBase, int64Size)
+	if err != nil {
+		return nil, p.invalidLiteral(token, "int", err)
 	}
 
-	var value int64
-	value, err = strconv.ParseInt(token.Value, intBase, int64Size)
+	return value, nil
+}
 
-	return value, errors.Wrap(err, "failed to parse int value")
+// invalidLiteral returns an error for a literal token that can not be converted into a value.
+func (p *parseState) invalidLiteral(token *tokenizer.Token, kind string, err error) error {
+	return InvalidLiteralError{
+		Diagnostic: errs.NewDiagnostic(p.tokenizer.GetQuery(), token.Start, len(token.Value), token.Value),
+		kind:       kind,
+		err:        err,
+	}
 }
 
 /*
@@ -529,7 +642,7 @@ func (p *Parser) numericLiteral() (interface{}, error) {
  * | <numeric_literal> "," <literal_list>
  * .
  */
-func (p *Parser) literalList() (bson.A, error) {
+func (p *parseState) literalList() (bson.A, error) {
 	items := bson.A{}
 
 	body, err := p.literal()
@@ -539,7 +652,7 @@ func (p *Parser) literalList() (bson.A, error) {
 
 	items = append(items, body)
 
-	for p.lookahead.Type == OrCompositeType {
+	for p.lookahead != nil && p.lookahead.Type == OrCompositeType {
 		_, err := p.eat(OrCompositeType)
 		if err != nil {
 			return nil, err
@@ -3,13 +3,17 @@ package rsql
 
 import (
 	"context"
+	"fmt"
 	"regexp"
 	"runtime"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/StevenCyb/go-mongo-tools/errs"
 	testutil "github.com/StevenCyb/go-mongo-tools/mongo/test_util"
 	"github.com/StevenCyb/go-mongo-tools/tokenizer"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -31,7 +35,10 @@ func TestQueryParsingWithInvalidQuery_Fail(t *testing.T) {
 	testutil.ExecuteFailedTest(t,
 		NewParser(nil),
 		"not_gonna_work",
-		errs.NewErrUnexpectedInputEnd(FieldNameType.String()),
+		errs.NewErrUnexpectedInputEndWithDiagnostic(errs.NewDiagnostic(
+			"not_gonna_work", 14, 0, "",
+			ValueCompareOperatorType.String(), QuotedStringValueCompareOperatorType.String(),
+			NumericValueCompareOperatorType.String(), ArrayCompareOperatorType.String())),
 	)
 }
 
@@ -499,7 +506,7 @@ func TestQueryParsingFailCases(t *testing.T) {
 		testutil.ExecuteFailedTest(t,
 			NewParser(nil),
 			`x=7`,
-			errs.NewErrUnexpectedToken(1, "="),
+			errs.NewErrUnexpectedTokenWithDiagnostic(errs.NewDiagnostic("x=7", 1, 1, "=")),
 		)
 	})
 
@@ -509,7 +516,8 @@ func TestQueryParsingFailCases(t *testing.T) {
 		testutil.ExecuteFailedTest(t,
 			NewParser(nil),
 			`x==7;`,
-			errs.NewErrUnexpectedInputEnd("FIELD_NAME"),
+			errs.NewErrUnexpectedInputEndWithDiagnostic(errs.NewDiagnostic(
+				"x==7;", 5, 0, "", "FIELD_NAME", "(", "MACRO")),
 		)
 	})
 
@@ -519,17 +527,35 @@ func TestQueryParsingFailCases(t *testing.T) {
 		testutil.ExecuteFailedTest(t,
 			NewParser(nil),
 			`x=in=3`,
-			errs.NewErrUnexpectedTokenType(6, "NUMERIC_LITERAL", "("),
+			errs.NewErrUnexpectedTokenTypeWithDiagnostic(errs.NewDiagnostic(
+				"x=in=3", 5, 1, "NUMERIC_LITERAL", "(")),
 		)
 	})
 
+	t.Run("WithInvalidLiteral_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		for query, expected := range map[string]errs.Diagnostic{
+			`_id==$oid(123)`:                errs.NewDiagnostic(`_id==$oid(123)`, 5, 9, "$oid(123)"),
+			`a==99999999999999999999`:       errs.NewDiagnostic(`a==99999999999999999999`, 3, 20, "99999999999999999999"),
+			`a=in=(1,-9223372036854775809)`: errs.NewDiagnostic(`a=in=(1,-9223372036854775809)`, 8, 20, "-9223372036854775809"),
+		} {
+			_, err := NewParser(nil).Parse(query)
+
+			var literalErr InvalidLiteralError
+			require.ErrorAs(t, err, &literalErr, query)
+			require.Equal(t, expected, literalErr.GetDiagnostic(), query)
+			require.ErrorIs(t, err, errs.ErrSyntax, query)
+		}
+	})
+
 	t.Run("WithNotClosedContext_Fail", func(t *testing.T) {
 		t.Parallel()
 
 		testutil.ExecuteFailedTest(t,
 			NewParser(nil),
 			`(x==7`,
-			errs.NewErrUnexpectedInputEnd(")"),
+			errs.NewErrUnexpectedInputEndWithDiagnostic(errs.NewDiagnostic("(x==7", 5, 0, "", ")")),
 		)
 	})
 }
@@ -566,11 +592,115 @@ func TestQueryParsingWithPolicy(t *testing.T) {
 			NewParser(
 				tokenizer.NewPolicy(tokenizer.WhitelistPolicy, "name", "age")),
 			`name=="steven",age=ge=18,gender="male"`,
-			errs.NewErrPolicyViolation("gender"),
+			errs.NewErrPolicyViolationWithDiagnostic(errs.NewDiagnostic(
+				`name=="steven",age=ge=18,gender="male"`, 25, 6, "gender")),
 		)
 	})
 }
 
+func TestQueryParsingWithContextPolicy(t *testing.T) {
+	t.Parallel()
+
+	type roleKey struct{}
+
+	parser := NewParser(tokenizer.Dynamic(func(ctx context.Context) tokenizer.FieldPolicy {
+		if ctx.Value(roleKey{}) == "admin" {
+			return nil
+		}
+
+		return tokenizer.NewPolicy(tokenizer.BlacklistPolicy, "salary")
+	}))
+
+	filter, err := parser.ParseContext(context.WithValue(context.Background(), roleKey{}, "admin"), `salary=gt=1`)
+	require.NoError(t, err)
+	require.Equal(t, bson.D{bson.E{Key: "salary", Value: bson.D{bson.E{Key: "$gt", Value: int64(1)}}}}, filter)
+
+	_, err = parser.ParseContext(context.WithValue(context.Background(), roleKey{}, "user"), `salary=gt=1`)
+	require.Equal(t, errs.NewErrPolicyViolationWithDiagnostic(errs.NewDiagnostic(`salary=gt=1`, 0, 6, "salary")), err)
+
+	_, err = parser.Parse(`salary=gt=1`)
+	require.Error(t, err)
+}
+
+func TestQueryParsingWithParameters(t *testing.T) {
+	t.Parallel()
+
+	oid := primitive.NewObjectID()
+	parameters := map[string]interface{}{
+		"tenant":   oid,
+		"statuses": []string{"open", "pending"},
+		"age":      18,
+		"prefix":   "a.b",
+		"name":     "steven",
+	}
+
+	t.Run("ScalarAndList_Success", func(t *testing.T) {
+		t.Parallel()
+
+		filter, err := NewParser(nil).ParseWithParameters(`tenant==:tenant;status=in=:statuses`, parameters)
+		require.NoError(t, err)
+		require.Equal(t, bson.D{
+			bson.E{Key: "$and", Value: bson.A{
+				bson.D{bson.E{Key: "tenant", Value: oid}},
+				bson.D{bson.E{Key: "status", Value: bson.E{Key: "$in", Value: bson.A{"open", "pending"}}}},
+			}},
+		}, filter)
+	})
+
+	t.Run("NumericAndInList_Success", func(t *testing.T) {
+		t.Parallel()
+
+		filter, err := NewParser(nil).ParseWithParameters(`age=ge=:age,name=in=(:name,"max")`, parameters)
+		require.NoError(t, err)
+		require.Equal(t, bson.D{
+			bson.E{Key: "$or", Value: bson.A{
+				bson.D{bson.E{Key: "age", Value: bson.D{bson.E{Key: "$gte", Value: int64(18)}}}},
+				bson.D{bson.E{Key: "name", Value: bson.E{Key: "$in", Value: bson.A{"steven", "max"}}}},
+			}},
+		}, filter)
+	})
+
+	t.Run("EscapedString_Success", func(t *testing.T) {
+		t.Parallel()
+
+		filter, err := NewParser(nil).ParseWithParameters(`path=sw=:prefix`, parameters)
+		require.NoError(t, err)
+		require.Equal(t, bson.D{bson.E{Key: "path", Value: *regexp.MustCompile(`^a\.b`)}}, filter)
+	})
+
+	t.Run("NotTokenized_Success", func(t *testing.T) {
+		t.Parallel()
+
+		filter, err := NewParser(nil).ParseWithParameters(`name==:name`,
+			map[string]interface{}{"name": `x";admin==true`})
+		require.NoError(t, err)
+		require.Equal(t, bson.D{bson.E{Key: "name", Value: `x";admin==true`}}, filter)
+	})
+
+	t.Run("Unbound_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		testutil.ExecuteFailedTest(t,
+			NewParser(nil),
+			`tenant==:tenant`,
+			UnboundParameterError{name: "tenant"},
+		)
+	})
+
+	t.Run("WrongType_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewParser(nil).ParseWithParameters(`age=gt=:name`, parameters)
+		require.Equal(t, ParameterTypeError{name: "name", expected: "number", actual: "string"}, err)
+
+		_, err = NewParser(nil).ParseWithParameters(`status=out=:age`, parameters)
+		require.Equal(t, ParameterTypeError{name: "age", expected: "list", actual: "int"}, err)
+
+		_, err = NewParser(nil).ParseWithParameters(`status==("a",:statuses)`, parameters)
+		require.Equal(t, ParameterTypeError{name: "statuses", expected: "scalar", actual: "[]string"}, err)
+	})
+}
+
 func TestInterpretation(t *testing.T) {
 	t.Parallel()
 
@@ -643,3 +773,91 @@ func TestInterpretation(t *testing.T) {
 		testutil.FindCompare(t, collection, filter, nil, items[1], items[2], items[3])
 	})
 }
+
+func newConcurrencyTestParser(t testing.TB) *Parser {
+	t.Helper()
+
+	policy, err := tokenizer.NewPatternPolicy(tokenizer.WhitelistPolicy,
+		tokenizer.Exact("name"), tokenizer.Exact("age"), tokenizer.Glob("address.*"))
+	require.NoError(t, err)
+
+	parser := NewParser(policy)
+	require.NoError(t, parser.RegisterMacro("adult", `age=ge=18`))
+	require.NoError(t, parser.RegisterTransformer("name", func(value interface{}) (interface{}, error) {
+		return strings.ToLower(fmt.Sprint(value)), nil
+	}))
+
+	return parser
+}
+
+func TestConcurrentParsing(t *testing.T) {
+	t.Parallel()
+
+	var (
+		parser  = newConcurrencyTestParser(t)
+		queries = []string{
+			`name=="Steven";@adult`,
+			`(address.city=="Berlin",address.zip=in=(1,2));age=lt=:max`,
+			`name=sw="St"`,
+			`password=="secret"`,
+			`age=gt=`,
+		}
+		parameters = map[string]interface{}{"max": 65}
+		expected   = make([]bson.D, len(queries))
+		expectErr  = make([]error, len(queries))
+		wait       sync.WaitGroup
+	)
+
+	for i, query := range queries {
+		expected[i], expectErr[i] = parser.ParseWithParameters(query, parameters)
+	}
+
+	for worker := 0; worker < 16; worker++ {
+		wait.Add(1)
+
+		go func(worker int) {
+			defer wait.Done()
+
+			for i := 0; i < 50; i++ {
+				index := (worker + i) % len(queries)
+				filter, err := parser.ParseWithParametersContext(context.Background(), queries[index], parameters)
+				assert.Equal(t, expectErr[index], err)
+				assert.Equal(t, expected[index], filter)
+			}
+		}(worker)
+	}
+
+	wait.Wait()
+}
+
+func BenchmarkParse(b *testing.B) {
+	parser := newConcurrencyTestParser(b)
+	query := `(name=="Steven",name=sw="St");@adult;address.city=in=("Berlin","Hamburg")`
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := parser.Parse(query)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseParallel(b *testing.B) {
+	parser := newConcurrencyTestParser(b)
+	query := `(name=="Steven",name=sw="St");@adult;address.city=in=("Berlin","Hamburg")`
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, err := parser.Parse(query)
+			if err != nil {
+				b.Error(err)
+			}
+		}
+	})
+}
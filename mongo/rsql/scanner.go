@@ -0,0 +1,213 @@
+package rsql
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/StevenCyb/go-mongo-tools/tokenizer"
+)
+
+// scan returns the rsql token at the start of given input.
+// Literals are tried before field names, in the order of `specs` in the tests.
+func scan(input string) (tokenizer.Type, int) { //nolint:cyclop
+	if length := tokenizer.ScanSpace(input); length > 0 {
+		return SkipType, length
+	}
+
+	switch input[0] {
+	case '(':
+		return ContextStartType, 1
+	case ')':
+		return ContextEndType, 1
+	case ';':
+		return AndCompositeType, 1
+	case ',':
+		return OrCompositeType, 1
+	}
+
+	if tokenType, length := scanOperator(input); length > 0 {
+		return tokenType, length
+	}
+
+	if length := scanOid(input); length > 0 {
+		return OidLiteralType, length
+	}
+
+	if length := scanBool(input); length > 0 {
+		return BoolLiteralType, length
+	}
+
+	if length := scanNumber(input); length > 0 {
+		return NumberLiteralType, length
+	}
+
+	if length := scanQuoted(input); length > 0 {
+		return QuotedStringLiteralType, length
+	}
+
+	if input[0] == ':' {
+		if length := scanName(input[1:]); length > 0 {
+			return PlaceholderType, length + 1
+		}
+	}
+
+	if input[0] == '@' {
+		if length := scanName(input[1:]); length > 0 {
+			return MacroType, length + 1
+		}
+	}
+
+	length := strings.IndexAny(input, "!=")
+	if length < 0 {
+		length = len(input)
+	}
+
+	return FieldNameType, length
+}
+
+// scanOperator scans a compare operator.
+func scanOperator(input string) (tokenizer.Type, int) {
+	switch {
+	case strings.HasPrefix(input, "=="), strings.HasPrefix(input, "!="):
+		return ValueCompareOperatorType, 2
+	case strings.HasPrefix(input, "=sw="), strings.HasPrefix(input, "=ew="):
+		return QuotedStringValueCompareOperatorType, 4
+	case strings.HasPrefix(input, "=gt="), strings.HasPrefix(input, "=ge="),
+		strings.HasPrefix(input, "=lt="), strings.HasPrefix(input, "=le="):
+		return NumericValueCompareOperatorType, 4
+	case strings.HasPrefix(input, "=in="):
+		return ArrayCompareOperatorType, 4
+	case strings.HasPrefix(input, "=out="):
+		return ArrayCompareOperatorType, 5
+	}
+
+	return "", 0
+}
+
+// scanOid scans an object id like `$oid(...)`.
+func scanOid(input string) int {
+	const prefix = "$oid("
+
+	if !strings.HasPrefix(input, prefix) {
+		return 0
+	}
+
+	length := len(prefix)
+	for length < len(input) && tokenizer.IsHex(input[length]) {
+		length++
+	}
+
+	if length == len(prefix) || length == len(input) || input[length] != ')' {
+		return 0
+	}
+
+	return length + 1
+}
+
+// scanBool scans `true` or `false` case-insensitive.
+func scanBool(input string) int {
+	if length := prefixFold(input, "true"); length > 0 {
+		return length
+	}
+
+	return prefixFold(input, "false")
+}
+
+// scanNumber scans an optionally signed integer or decimal.
+func scanNumber(input string) int {
+	length := 0
+	if input[0] == '-' || input[0] == '+' {
+		length++
+	}
+
+	digits := scanDigits(input[length:])
+	if digits == 0 {
+		return 0
+	}
+
+	length += digits
+
+	if length < len(input) && input[length] == '.' {
+		if fraction := scanDigits(input[length+1:]); fraction > 0 {
+			length += 1 + fraction
+		}
+	}
+
+	return length
+}
+
+// scanQuoted scans a single or double quoted string.
+func scanQuoted(input string) int {
+	if input[0] != '"' && input[0] != '\'' {
+		return 0
+	}
+
+	end := strings.IndexByte(input[1:], input[0])
+	if end < 0 {
+		return 0
+	}
+
+	return end + 2
+}
+
+// scanDigits scans ASCII digits.
+func scanDigits(input string) int {
+	length := 0
+	for length < len(input) && tokenizer.IsDigit(input[length]) {
+		length++
+	}
+
+	return length
+}
+
+// scanName scans an identifier like `[a-zA-Z_][a-zA-Z0-9_]*`.
+func scanName(input string) int {
+	length := 0
+	for length < len(input) && (isNameCharacter(input[length]) || (length > 0 && tokenizer.IsDigit(input[length]))) {
+		length++
+	}
+
+	return length
+}
+
+// prefixFold returns the length of the case-insensitive prefix
+// or zero if input does not start with it.
+func prefixFold(input, prefix string) int {
+	length := 0
+
+	for _, expected := range prefix {
+		if length >= len(input) {
+			return 0
+		}
+
+		actual, size := utf8.DecodeRuneInString(input[length:])
+		if !equalFold(actual, expected) {
+			return 0
+		}
+
+		length += size
+	}
+
+	return length
+}
+
+// equalFold reports whether both runes are equal under simple Unicode case folding.
+func equalFold(a, b rune) bool {
+	if a == b {
+		return true
+	}
+
+	for fold := unicode.SimpleFold(b); fold != b; fold = unicode.SimpleFold(fold) {
+		if fold == a {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isNameCharacter reports whether given character can start a name like `[a-zA-Z_]`.
+func isNameCharacter(character byte) bool {
+	return tokenizer.IsLetter(character) || character == '_'
+}
@@ -0,0 +1,114 @@
+package rsql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/StevenCyb/go-mongo-tools/tokenizer"
+	"github.com/stretchr/testify/require"
+)
+
+// specs are the regular expressions of the rsql grammar,
+// the scanner must produce the same tokens.
+//
+//nolint:gochecknoglobals
+var specs = []*tokenizer.Spec{
+	tokenizer.NewSpec(`^\s+`, SkipType),
+	tokenizer.NewSpec(`^\(`, ContextStartType),
+	tokenizer.NewSpec(`^\)`, ContextEndType),
+	tokenizer.NewSpec(`^;`, AndCompositeType),
+	tokenizer.NewSpec(`^,`, OrCompositeType),
+	tokenizer.NewSpec(`^(==|!=)`, ValueCompareOperatorType),
+	tokenizer.NewSpec(`^(=sw=|=ew=)`, QuotedStringValueCompareOperatorType),
+	tokenizer.NewSpec(`^(=gt=|=ge=|=lt=|=le=)`, NumericValueCompareOperatorType),
+	tokenizer.NewSpec(`^(=in=|=out=)`, ArrayCompareOperatorType),
+	tokenizer.NewSpec(`^\$oid\([0-9a-fA-F]+\)`, OidLiteralType),
+	tokenizer.NewSpec(`(?i)^(true|false)`, BoolLiteralType),
+	tokenizer.NewSpec(`^(-|\+)?\d+(\.\d+)?`, NumberLiteralType),
+	tokenizer.NewSpec(`^("[^"]*"|'[^']*')`, QuotedStringLiteralType),
+	tokenizer.NewSpec(`^:[a-zA-Z_][a-zA-Z0-9_]*`, PlaceholderType),
+	tokenizer.NewSpec(`^@[a-zA-Z_][a-zA-Z0-9_]*`, MacroType),
+	tokenizer.NewSpec(`^[^!=]*`, FieldNameType),
+}
+
+//nolint:gochecknoglobals
+var scannerCorpus = []string{
+	``,
+	`a==1`,
+	`  name == "Steven" ; age=ge=18 `,
+	`(a=in=(1,"2",'3'),b=out=(true,FALSE));c!=-1.5`,
+	`id==$oid(5f3c7a4e9b1d8c2a6e4f1b3d)`,
+	`id==$oid()`,
+	`id==$oid(5f3c`,
+	`a=sw="St";b=ew='en';c=gt=+3;d=lt=1.;e=le=.5`,
+	`a==:name;@adult,@x1`,
+	`a==: ;b==@`,
+	`a==TrUe;b==falſe;c==truest`,
+	"a\t==\n1\r\f;b==\v2",
+	`a=="unterminated`,
+	`a=x=1`,
+	`!a==1`,
+	`ä.ö==ü`,
+	"a==\xff",
+	`a==1;` + strings.Repeat(" ", 1000) + `b==2`,
+}
+
+// requireSameTokens compares the token stream of the scanner with the one of the specs.
+func requireSameTokens(t *testing.T, query string) {
+	t.Helper()
+
+	expected, expectedErr := tokenizer.NewTokenizer(query, SkipType, FieldNameType, specs, nil).Tokenize()
+	actual, actualErr := tokenizer.NewScannerTokenizer(query, SkipType, FieldNameType, scan, nil).Tokenize()
+
+	require.Equal(t, expected, actual, query)
+	require.Equal(t, expectedErr, actualErr, query)
+}
+
+func TestScanner(t *testing.T) {
+	t.Parallel()
+
+	for _, query := range scannerCorpus {
+		requireSameTokens(t, query)
+	}
+}
+
+//nolint:paralleltest // AllocsPerRun must not run in parallel
+func TestScannerWithoutAllocations(t *testing.T) {
+	query := `(name=="Steven",age=gt=18);id==$oid(5f3c7a4e9b1d8c2a6e4f1b3d);@adult`
+	allocations := testing.AllocsPerRun(100, func() {
+		for part := query; part != ""; {
+			_, length := scan(part)
+			part = part[length:]
+		}
+	})
+
+	require.Zero(t, allocations)
+}
+
+func FuzzScanner(f *testing.F) {
+	for _, query := range scannerCorpus {
+		f.Add(query)
+	}
+
+	f.Fuzz(requireSameTokens)
+}
+
+func BenchmarkTokenizer(b *testing.B) {
+	query := strings.Repeat(`(name=="Steven",name=sw="St");age=ge=18;tags=in=('a','b',"c");`, 50) + `@adult`
+
+	b.Run("Regex", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			_, _ = tokenizer.NewTokenizer(query, SkipType, FieldNameType, specs, nil).Tokenize()
+		}
+	})
+
+	b.Run("Scanner", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			_, _ = tokenizer.NewScannerTokenizer(query, SkipType, FieldNameType, scan, nil).Tokenize()
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package rsql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+	"github.com/StevenCyb/go-mongo-tools/tokenizer"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// NewScopedParser creates a new parser that always combines
+// parsed queries with given server-side scope filter.
+func NewScopedParser(policy tokenizer.FieldPolicy, scope bson.D) *ScopedParser {
+	return &ScopedParser{
+		parser: NewParser(policy),
+		scope:  scope,
+		fields: filterFields(scope),
+	}
+}
+
+// ScopedParser parses rsql statements and restricts
+// them to a mandatory scope e.g. a tenant or owner.
+type ScopedParser struct {
+	parser *Parser
+	scope  bson.D
+	fields []string
+}
+
+// Parse a given query and combine it with the scope.
+func (s *ScopedParser) Parse(query string) (bson.D, error) {
+	return s.ParseWithParametersContext(context.Background(), query, nil)
+}
+
+// ParseContext parses a given query, passes the context to the policy and combine it with the scope.
+func (s *ScopedParser) ParseContext(ctx context.Context, query string) (bson.D, error) {
+	return s.ParseWithParametersContext(ctx, query, nil)
+}
+
+// ParseWithParameters parses a given query with parameters and combine it with the scope.
+// Queries that reference fields of the scope are rejected with a policy violation.
+func (s *ScopedParser) ParseWithParameters(query string, parameters map[string]interface{}) (bson.D, error) {
+	return s.ParseWithParametersContext(context.Background(), query, parameters)
+}
+
+// ParseWithParametersContext parses a given query with parameters,
+// passes the context to the policy and combine it with the scope.
+func (s *ScopedParser) ParseWithParametersContext(
+	ctx context.Context, query string, parameters map[string]interface{},
+) (bson.D, error) {
+	filter, err := s.parser.ParseWithParametersContext(ctx, query, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range filterFields(filter) {
+		for _, scopeField := range s.fields {
+			if fieldsOverlap(field, scopeField) {
+				return nil, errs.NewErrPolicyViolation(field)
+			}
+		}
+	}
+
+	scope := make(bson.D, len(s.scope))
+	copy(scope, s.scope)
+
+	if len(filter) == 0 {
+		return scope, nil
+	}
+
+	return bson.D{bson.E{Key: "$and", Value: bson.A{scope, filter}}}, nil
+}
+
+// filterFields returns all field names referenced by a filter.
+func filterFields(filter bson.D) []string {
+	fields := []string{}
+
+	for _, element := range filter {
+		if !strings.HasPrefix(element.Key, "$") {
+			fields = append(fields, element.Key)
+
+			continue
+		}
+
+		documents, ok := toDocuments(element.Value)
+		if !ok {
+			continue
+		}
+
+		for _, document := range documents {
+			fields = append(fields, filterFields(document)...)
+		}
+	}
+
+	return fields
+}
+
+// fieldsOverlap checks if fields are equal or one is the parent of the other.
+func fieldsOverlap(a, b string) bool {
+	return a == b || strings.HasPrefix(a, b+".") || strings.HasPrefix(b, a+".")
+}
+
+// RegisterMacro registers a named rsql fragment that can be used as `@name` inside queries.
+func (s *ScopedParser) RegisterMacro(name, expression string) error {
+	return s.parser.RegisterMacro(name, expression)
+}
@@ -0,0 +1,75 @@
+package rsql
+
+import (
+	"testing"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+	testutil "github.com/StevenCyb/go-mongo-tools/mongo/test_util"
+	"github.com/StevenCyb/go-mongo-tools/tokenizer"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestScopedParser(t *testing.T) {
+	t.Parallel()
+
+	scope := bson.D{bson.E{Key: "tenant", Value: "acme"}}
+
+	t.Run("WithEmptyQuery_Success", func(t *testing.T) {
+		t.Parallel()
+
+		testutil.ExecuteSuccessTest(t,
+			NewScopedParser(nil, scope),
+			"",
+			bson.D{bson.E{Key: "tenant", Value: "acme"}},
+		)
+	})
+
+	t.Run("WithOrQuery_Success", func(t *testing.T) {
+		t.Parallel()
+
+		testutil.ExecuteSuccessTest(t,
+			NewScopedParser(nil, scope),
+			`a==1,b==2`,
+			bson.D{bson.E{Key: "$and", Value: bson.A{
+				bson.D{bson.E{Key: "tenant", Value: "acme"}},
+				bson.D{bson.E{Key: "$or", Value: bson.A{
+					bson.D{bson.E{Key: "a", Value: int64(1)}},
+					bson.D{bson.E{Key: "b", Value: int64(2)}},
+				}}},
+			}}},
+		)
+	})
+
+	t.Run("WithScopedField_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		testutil.ExecuteFailedTest(t,
+			NewScopedParser(nil, scope),
+			`a==1,tenant=="other"`,
+			errs.NewErrPolicyViolation("tenant"),
+		)
+	})
+
+	t.Run("WithNestedScopedField_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		testutil.ExecuteFailedTest(t,
+			NewScopedParser(nil, bson.D{bson.E{Key: "$or", Value: bson.A{
+				bson.D{bson.E{Key: "owner", Value: "me"}},
+				bson.D{bson.E{Key: "public", Value: true}},
+			}}}),
+			`owner.name=="other"`,
+			errs.NewErrPolicyViolation("owner.name"),
+		)
+	})
+
+	t.Run("WithPolicy_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		testutil.ExecuteFailedTest(t,
+			NewScopedParser(tokenizer.NewPolicy(tokenizer.WhitelistPolicy, "a"), scope),
+			`b==1`,
+			errs.NewErrPolicyViolationWithDiagnostic(errs.NewDiagnostic("b==1", 0, 1, "b")),
+		)
+	})
+}
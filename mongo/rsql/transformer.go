@@ -0,0 +1,70 @@
+package rsql
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Transformer transforms a parsed literal of a field before it is used in the filter.
+// Returning an error rejects the literal.
+type Transformer func(value interface{}) (interface{}, error)
+
+// RegisterTransformer registers a transformer for given field path.
+// Transformers of the same field are applied in order of registration
+// to every literal, including every item of lists.
+func (p *Parser) RegisterTransformer(field string, transformer Transformer) error {
+	if transformer == nil {
+		return ErrNilTransformer
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.transformers[field] = append(p.transformers[field], transformer)
+
+	return nil
+}
+
+// transform applies the transformers of the field on a literal or each item of a list.
+func (p *parseState) transform(field string, literal interface{}) (interface{}, error) {
+	if list, ok := literal.(bson.A); ok {
+		return p.transformList(field, list)
+	}
+
+	return p.transformValue(field, literal)
+}
+
+// transformList applies the transformers of the field on each item of a list.
+func (p *parseState) transformList(field string, list bson.A) (bson.A, error) {
+	if len(p.transformers[field]) == 0 {
+		return list, nil
+	}
+
+	transformed := make(bson.A, 0, len(list))
+
+	for _, item := range list {
+		value, err := p.transformValue(field, item)
+		if err != nil {
+			return nil, err
+		}
+
+		transformed = append(transformed, value)
+	}
+
+	return transformed, nil
+}
+
+// transformValue applies the transformers of the field on a single value.
+func (p *parseState) transformValue(field string, value interface{}) (interface{}, error) {
+	var err error
+
+	for _, transformer := range p.transformers[field] {
+		original := value
+
+		value, err = transformer(value)
+		if err != nil {
+			return nil, TransformError{field: field, value: original, err: err}
+		}
+	}
+
+	return value, nil
+}
@@ -0,0 +1,101 @@
+//nolint:funlen
+package rsql
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	testutil "github.com/StevenCyb/go-mongo-tools/mongo/test_util"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var errUnknownStatus = errors.New("unknown status")
+
+func TestQueryParsingWithTransformers(t *testing.T) {
+	t.Parallel()
+
+	newParser := func() *Parser {
+		parser := NewParser(nil)
+		require.NoError(t, parser.RegisterTransformer("email", func(value interface{}) (interface{}, error) {
+			return strings.ToLower(fmt.Sprintf("%v", value)), nil
+		}))
+		require.NoError(t, parser.RegisterTransformer("email", func(value interface{}) (interface{}, error) {
+			return strings.TrimSpace(fmt.Sprintf("%v", value)), nil
+		}))
+		require.NoError(t, parser.RegisterTransformer("status", func(value interface{}) (interface{}, error) {
+			switch value {
+			case "open":
+				return int64(1), nil
+			case "closed":
+				return int64(2), nil
+			}
+
+			return nil, errUnknownStatus
+		}))
+
+		return parser
+	}
+
+	t.Run("NilTransformer_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, ErrNilTransformer, NewParser(nil).RegisterTransformer("a", nil))
+	})
+
+	t.Run("ChainedTransformers_Success", func(t *testing.T) {
+		t.Parallel()
+
+		testutil.ExecuteSuccessTest(t,
+			newParser(),
+			`email==" Steven@Example.COM "`,
+			bson.D{bson.E{Key: "email", Value: "steven@example.com"}},
+		)
+	})
+
+	t.Run("EachListItem_Success", func(t *testing.T) {
+		t.Parallel()
+
+		testutil.ExecuteSuccessTest(t,
+			newParser(),
+			`status=in=("open","closed")`,
+			bson.D{bson.E{Key: "status", Value: bson.E{Key: "$in", Value: bson.A{int64(1), int64(2)}}}},
+		)
+		testutil.ExecuteSuccessTest(t,
+			newParser(),
+			`status!=("open")`,
+			bson.D{bson.E{Key: "status", Value: bson.D{bson.E{Key: "$ne", Value: bson.A{int64(1)}}}}},
+		)
+	})
+
+	t.Run("BeforeWildcard_Success", func(t *testing.T) {
+		t.Parallel()
+
+		testutil.ExecuteSuccessTest(t,
+			newParser(),
+			`email=ew="@EXAMPLE.com"`,
+			bson.D{bson.E{Key: "email", Value: *regexp.MustCompile("@example.com$")}},
+		)
+	})
+
+	t.Run("OtherFieldsUntouched_Success", func(t *testing.T) {
+		t.Parallel()
+
+		testutil.ExecuteSuccessTest(t,
+			newParser(),
+			`name=="Steven"`,
+			bson.D{bson.E{Key: "name", Value: "Steven"}},
+		)
+	})
+
+	t.Run("Rejected_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newParser().Parse(`status=out=("open","pending")`)
+		require.Equal(t, TransformError{field: "status", value: "pending", err: errUnknownStatus}, err)
+		require.ErrorIs(t, err, errUnknownStatus)
+	})
+}
@@ -0,0 +1,239 @@
+package sort
+
+import (
+	"fmt"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+)
+
+var ErrReferenceIsNil = errs.NewErrSentinel(errs.ErrType, errs.CodeNilReference, "reference is nil")
+
+// sortName returns the name of given sort value.
+func sortName(sort int) string {
+	if sort < 0 {
+		return "desc"
+	}
+
+	return "asc"
+}
+
+// InvalidSortTagError indicate that the `sort` tag of a reference field is invalid.
+type InvalidSortTagError struct {
+	path  string
+	value string
+}
+
+func (i InvalidSortTagError) Error() string {
+	return fmt.Sprintf("sort tag '%s' of '%s' is invalid, must be true, false, asc or desc", i.value, i.path)
+}
+
+// Path returns the path of the field with the invalid tag.
+func (i InvalidSortTagError) Path() string {
+	return i.path
+}
+
+// Value returns the invalid tag value.
+func (i InvalidSortTagError) Value() string {
+	return i.value
+}
+
+// Code returns the error code.
+func (i InvalidSortTagError) Code() string {
+	return errs.CodeInvalidTag
+}
+
+// Details returns the parameters of the error.
+func (i InvalidSortTagError) Details() map[string]interface{} {
+	return map[string]interface{}{"path": i.path, "value": i.value}
+}
+
+// Is reports whether the error belongs to given category.
+func (i InvalidSortTagError) Is(target error) bool {
+	return target == errs.ErrSyntax
+}
+
+// UnsortableFieldError indicate that a field is unknown or not sortable.
+type UnsortableFieldError struct {
+	field string
+}
+
+func (u UnsortableFieldError) Error() string {
+	return fmt.Sprintf("field '%s' is not sortable", u.field)
+}
+
+// Path returns the field that is not sortable.
+func (u UnsortableFieldError) Path() string {
+	return u.field
+}
+
+// Code returns the error code.
+func (u UnsortableFieldError) Code() string {
+	return errs.CodeUnsortableField
+}
+
+// Details returns the parameters of the error.
+func (u UnsortableFieldError) Details() map[string]interface{} {
+	return map[string]interface{}{"field": u.field}
+}
+
+// Is reports whether the error belongs to given category.
+func (u UnsortableFieldError) Is(target error) bool {
+	return target == errs.ErrPolicy
+}
+
+// DirectionNotAllowedError indicate that a field may not be sorted in requested direction.
+type DirectionNotAllowedError struct {
+	field string
+	sort  int
+}
+
+func (d DirectionNotAllowedError) Error() string {
+	return fmt.Sprintf("field '%s' can not be sorted %s", d.field, sortName(d.sort))
+}
+
+// Path returns the field of the disallowed direction.
+func (d DirectionNotAllowedError) Path() string {
+	return d.field
+}
+
+// Sort returns the disallowed direction as `1` or `-1`.
+func (d DirectionNotAllowedError) Sort() int {
+	return d.sort
+}
+
+// Code returns the error code.
+func (d DirectionNotAllowedError) Code() string {
+	return errs.CodeSortDirection
+}
+
+// Details returns the parameters of the error.
+func (d DirectionNotAllowedError) Details() map[string]interface{} {
+	return map[string]interface{}{"field": d.field, "direction": sortName(d.sort)}
+}
+
+// Is reports whether the error belongs to given category.
+func (d DirectionNotAllowedError) Is(target error) bool {
+	return target == errs.ErrPolicy
+}
+
+// DuplicateKeyError indicate that a field is used more than once.
+type DuplicateKeyError struct {
+	field string
+}
+
+func (d DuplicateKeyError) Error() string {
+	return fmt.Sprintf("field '%s' is sorted more than once", d.field)
+}
+
+// Path returns the duplicate field.
+func (d DuplicateKeyError) Path() string {
+	return d.field
+}
+
+// Code returns the error code.
+func (d DuplicateKeyError) Code() string {
+	return errs.CodeDuplicateKey
+}
+
+// Details returns the parameters of the error.
+func (d DuplicateKeyError) Details() map[string]interface{} {
+	return map[string]interface{}{"field": d.field}
+}
+
+// Is reports whether the error belongs to given category.
+func (d DuplicateKeyError) Is(target error) bool {
+	return target == errs.ErrConstraint
+}
+
+// KeyLimitError indicate that an expression has too many sort keys.
+type KeyLimitError struct {
+	limit int
+}
+
+func (k KeyLimitError) Error() string {
+	return fmt.Sprintf("sort exceeds limit of %d keys", k.limit)
+}
+
+// Limit returns the exceeded limit.
+func (k KeyLimitError) Limit() int {
+	return k.limit
+}
+
+// Code returns the error code.
+func (k KeyLimitError) Code() string {
+	return errs.CodeKeyLimit
+}
+
+// Path returns an empty string since the limit has no path.
+func (k KeyLimitError) Path() string {
+	return ""
+}
+
+// Details returns the parameters of the error.
+func (k KeyLimitError) Details() map[string]interface{} {
+	return map[string]interface{}{"limit": k.limit}
+}
+
+// Is reports whether the error belongs to given category.
+func (k KeyLimitError) Is(target error) bool {
+	return target == errs.ErrConstraint
+}
+
+// PipelineRequiredError indicate that an expression uses functions or modifiers
+// that can only be applied by `ParsePipeline`.
+type PipelineRequiredError struct {
+	field string
+}
+
+func (p PipelineRequiredError) Error() string {
+	return fmt.Sprintf("sort of field '%s' requires an aggregation pipeline", p.field)
+}
+
+// Path returns the first field that requires a pipeline.
+func (p PipelineRequiredError) Path() string {
+	return p.field
+}
+
+// Code returns the error code.
+func (p PipelineRequiredError) Code() string {
+	return errs.CodePipelineRequired
+}
+
+// Details returns the parameters of the error.
+func (p PipelineRequiredError) Details() map[string]interface{} {
+	return map[string]interface{}{"field": p.field}
+}
+
+// Is reports whether the error belongs to given category.
+func (p PipelineRequiredError) Is(target error) bool {
+	return target == errs.ErrConstraint
+}
+
+// TextSearchRequiredError indicate that a field is sorted by the text search score without a text search.
+type TextSearchRequiredError struct {
+	field string
+}
+
+func (t TextSearchRequiredError) Error() string {
+	return fmt.Sprintf("sort of field '%s' by text score requires a text search", t.field)
+}
+
+// Path returns the field that is sorted by the text search score.
+func (t TextSearchRequiredError) Path() string {
+	return t.field
+}
+
+// Code returns the error code.
+func (t TextSearchRequiredError) Code() string {
+	return errs.CodeTextSearchRequired
+}
+
+// Details returns the parameters of the error.
+func (t TextSearchRequiredError) Details() map[string]interface{} {
+	return map[string]interface{}{"field": t.field}
+}
+
+// Is reports whether the error belongs to given category.
+func (t TextSearchRequiredError) Is(target error) bool {
+	return target == errs.ErrConstraint
+}
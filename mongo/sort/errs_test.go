@@ -0,0 +1,80 @@
+package sort
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvalidSortTagError(t *testing.T) {
+	t.Parallel()
+
+	err := InvalidSortTagError{path: "name", value: "yes"}
+	require.Equal(t, "sort tag 'yes' of 'name' is invalid, must be true, false, asc or desc", err.Error())
+	require.Equal(t, errs.CodeInvalidTag, err.Code())
+	require.True(t, errors.Is(err, errs.ErrSyntax))
+}
+
+func TestUnsortableFieldError(t *testing.T) {
+	t.Parallel()
+
+	err := UnsortableFieldError{field: "password"}
+	require.Equal(t, "field 'password' is not sortable", err.Error())
+	require.Equal(t, "password", err.Path())
+	require.True(t, errors.Is(err, errs.ErrPolicy))
+}
+
+func TestDirectionNotAllowedError(t *testing.T) {
+	t.Parallel()
+
+	err := DirectionNotAllowedError{field: "age", sort: -1}
+	require.Equal(t, "field 'age' can not be sorted desc", err.Error())
+	require.Equal(t, map[string]interface{}{"field": "age", "direction": "desc"}, err.Details())
+	require.True(t, errors.Is(err, errs.ErrPolicy))
+}
+
+func TestDuplicateKeyError(t *testing.T) {
+	t.Parallel()
+
+	err := DuplicateKeyError{field: "age"}
+	require.Equal(t, "field 'age' is sorted more than once", err.Error())
+	require.True(t, errors.Is(err, errs.ErrConstraint))
+}
+
+func TestKeyLimitError(t *testing.T) {
+	t.Parallel()
+
+	err := KeyLimitError{limit: 2}
+	require.Equal(t, "sort exceeds limit of 2 keys", err.Error())
+	require.Equal(t, 2, err.Limit())
+	require.True(t, errors.Is(err, errs.ErrConstraint))
+}
+
+func TestPipelineRequiredError(t *testing.T) {
+	t.Parallel()
+
+	err := PipelineRequiredError{field: "name"}
+	require.Equal(t, "sort of field 'name' requires an aggregation pipeline", err.Error())
+	require.Equal(t, "name", err.Path())
+	require.Equal(t, errs.CodePipelineRequired, err.Code())
+	require.True(t, errors.Is(err, errs.ErrConstraint))
+}
+
+func TestTextSearchRequiredError(t *testing.T) {
+	t.Parallel()
+
+	err := TextSearchRequiredError{field: "score"}
+	require.Equal(t, "sort of field 'score' by text score requires a text search", err.Error())
+	require.Equal(t, "score", err.Path())
+	require.Equal(t, errs.CodeTextSearchRequired, err.Code())
+	require.True(t, errors.Is(err, errs.ErrConstraint))
+}
+
+func TestReferenceIsNilError(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, errs.CodeNilReference, ErrReferenceIsNil.Code())
+	require.True(t, errors.Is(ErrReferenceIsNil, errs.ErrType))
+}
@@ -0,0 +1,93 @@
+package sort
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Capability of a filter that is required by some sort keys.
+type Capability int
+
+const (
+	// TextSearch indicates a filter with a `$text` query, required to sort by `meta`.
+	TextSearch Capability = 1 << iota
+)
+
+// metaSort is the sort condition for the text search score.
+const metaSort = "meta"
+
+// capabilitiesKey is the context key of the capabilities.
+type capabilitiesKey struct{}
+
+// WithCapabilities returns a context that tells the parser about the capabilities of the filter.
+func WithCapabilities(ctx context.Context, capabilities Capability) context.Context {
+	return context.WithValue(ctx, capabilitiesKey{}, capabilities)
+}
+
+// CapabilitiesFrom returns the capabilities of given context.
+func CapabilitiesFrom(ctx context.Context) Capability {
+	capabilities, _ := ctx.Value(capabilitiesKey{}).(Capability)
+
+	return capabilities
+}
+
+// CapabilitiesOf returns the capabilities of given filter,
+// e.g. `TextSearch` if it has a `$text` query on top level or inside `$and`/`$or`.
+func CapabilitiesOf(filter interface{}) Capability {
+	var capabilities Capability
+
+	switch filter := filter.(type) {
+	case bson.D:
+		for _, element := range filter {
+			capabilities |= capabilitiesOfElement(element.Key, element.Value)
+		}
+	case bson.M:
+		for key, value := range filter {
+			capabilities |= capabilitiesOfElement(key, value)
+		}
+	case bson.A:
+		for _, value := range filter {
+			capabilities |= CapabilitiesOf(value)
+		}
+	case []bson.D:
+		for _, value := range filter {
+			capabilities |= CapabilitiesOf(value)
+		}
+	case []interface{}:
+		return CapabilitiesOf(bson.A(filter))
+	}
+
+	return capabilities
+}
+
+// capabilitiesOfElement returns the capabilities of an element of a filter.
+func capabilitiesOfElement(key string, value interface{}) Capability {
+	switch key {
+	case "$text":
+		return TextSearch
+	case "$and", "$or":
+		return CapabilitiesOf(value)
+	}
+
+	return 0
+}
+
+// MetaProjection returns the projection of the `$meta` keys of given sort,
+// which is required by servers before MongoDB 4.4.
+func MetaProjection(sort bson.D) bson.D {
+	projection := bson.D{}
+
+	for _, element := range sort {
+		if meta, ok := element.Value.(bson.D); ok && len(meta) == 1 && meta[0].Key == "$meta" {
+			projection = append(projection, element)
+		}
+	}
+
+	return projection
+}
+
+// textScore returns the sort value of the text search score.
+func textScore() bson.D {
+	return bson.D{{Key: "$meta", Value: "textScore"}}
+}
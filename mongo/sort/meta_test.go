@@ -0,0 +1,40 @@
+package sort
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestCapabilities(t *testing.T) {
+	t.Parallel()
+
+	text := bson.D{{Key: "$search", Value: "coffee"}}
+
+	require.Equal(t, TextSearch, CapabilitiesOf(bson.D{{Key: "$text", Value: text}}))
+	require.Equal(t, TextSearch, CapabilitiesOf(bson.M{"$and": bson.A{
+		bson.D{{Key: "a", Value: 1}},
+		bson.M{"$text": text},
+	}}))
+	require.Equal(t, TextSearch, CapabilitiesOf(bson.D{{Key: "$or", Value: []bson.D{{{Key: "$text", Value: text}}}}}))
+	require.Equal(t, Capability(0), CapabilitiesOf(bson.D{{Key: "a", Value: bson.D{{Key: "$text", Value: text}}}}))
+	require.Equal(t, Capability(0), CapabilitiesOf(nil))
+
+	require.Equal(t, Capability(0), CapabilitiesFrom(context.Background()))
+	require.Equal(t, TextSearch, CapabilitiesFrom(WithCapabilities(context.Background(), TextSearch)))
+}
+
+func TestMetaProjection(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t,
+		bson.D{{Key: "score", Value: bson.D{{Key: "$meta", Value: "textScore"}}}},
+		MetaProjection(bson.D{
+			{Key: "score", Value: bson.D{{Key: "$meta", Value: "textScore"}}},
+			{Key: "_id", Value: 1},
+		}),
+	)
+	require.Equal(t, bson.D{}, MetaProjection(bson.D{{Key: "_id", Value: 1}}))
+}
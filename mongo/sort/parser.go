@@ -1,7 +1,9 @@
 package sort
 
 import (
+	"context"
 	"strings"
+	"sync"
 
 	"github.com/StevenCyb/go-mongo-tools/errs"
 	"github.com/StevenCyb/go-mongo-tools/tokenizer"
@@ -15,6 +17,19 @@ const (
 	SetType           tokenizer.Type = "="
 	SortConditionType tokenizer.Type = "SORT_CRITERIA"
 	FieldNameType     tokenizer.Type = "FIELD_NAME"
+	DirectionType     tokenizer.Type = "DIRECTION"
+	FunctionType      tokenizer.Type = "FUNCTION"
+	ModifierType      tokenizer.Type = "MODIFIER"
+)
+
+// Syntax of sort expressions.
+type Syntax int
+
+const (
+	// KeyValueSyntax declares expressions like `name=asc,age=-1`.
+	KeyValueSyntax Syntax = iota
+	// PrefixSyntax declares JSON:API style expressions like `-age,+name,title`.
+	PrefixSyntax
 )
 
 // specialEncode is the map for encoding
@@ -27,89 +42,169 @@ var specialEncode = map[string]string{
 	` `: "%20",
 }
 
-// NewParser creates a new parser.
-func NewParser(policy *tokenizer.Policy) *Parser {
-	return &Parser{
+// NewParser creates a new parser that uses the `KeyValueSyntax` or an optional given syntax.
+func NewParser(policy tokenizer.FieldPolicy, syntax ...Syntax) *Parser {
+	parser := &Parser{
 		policy: policy,
+		syntax: KeyValueSyntax,
 	}
+
+	if len(syntax) > 0 {
+		parser.syntax = syntax[0]
+	}
+
+	return parser
 }
 
-// Parser provides the logic to parse rsql statements.
+// Parser provides the logic to parse sort statements.
+// Goroutines may share a parser, a parsing holds the read lock
+// so the sortable fields and the key limit do not change halfway.
 type Parser struct {
-	tokenizer *tokenizer.Tokenizer
-	lookahead *tokenizer.Token
-	policy    *tokenizer.Policy
+	policy   tokenizer.FieldPolicy
+	sortable map[string]direction
+	mutex    sync.RWMutex
+	syntax   Syntax
+	keyLimit int
+}
+
+// parseState holds the state of a single parsing.
+type parseState struct {
+	*Parser
+	tokenizer    *tokenizer.Tokenizer
+	lookahead    *tokenizer.Token
+	keys         map[string]bool
+	computed     []computedKey
+	count        int
+	capabilities Capability
+}
+
+// SetKeyLimit sets the maximum number of sort keys, zero disables the limit.
+func (p *Parser) SetKeyLimit(limit int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.keyLimit = limit
 }
 
 // eat return a token with expected type.
-func (p *Parser) eat(tokenType tokenizer.Type) (*tokenizer.Token, error) {
+func (p *parseState) eat(tokenType tokenizer.Type) (*tokenizer.Token, error) {
 	token := p.lookahead
 
-	if token == nil {
-		return nil, errs.NewErrUnexpectedInputEnd(tokenType.String())
+	if token == nil || token.Type != tokenType {
+		return nil, p.tokenizer.Unexpected(p.lookahead, tokenType)
 	}
 
-	if token.Type != tokenType {
-		return nil, errs.NewErrUnexpectedTokenType(
-			p.tokenizer.GetCursorPosition(),
-			token.Type.String(),
-			tokenType.String(),
-		)
-	}
+	return token, p.next()
+}
 
+// next fetches the next token as lookahead.
+func (p *parseState) next() error {
 	var err error
+
 	p.lookahead, err = p.tokenizer.GetNextToken()
 
-	return token, err //nolint:wrapcheck
+	return err //nolint:wrapcheck
 }
 
 // Parse a given query.
 func (p *Parser) Parse(query string) (bson.D, error) {
-	var err error
+	return p.ParseContext(context.Background(), query)
+}
+
+// ParseContext parses a given query and passes the context to the policy.
+// Queries with functions or modifiers fail with `PipelineRequiredError`.
+func (p *Parser) ParseContext(ctx context.Context, query string) (bson.D, error) {
+	sort, computed, err := p.parse(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(computed) > 0 {
+		return nil, PipelineRequiredError{field: sort[computed[0].index].Key}
+	}
+
+	return sort, nil
+}
+
+// ParseWithProjection parses a given query into the sort and the projection that the sort requires.
+func (p *Parser) ParseWithProjection(query string) (bson.D, bson.D, error) {
+	return p.ParseWithProjectionContext(context.Background(), query)
+}
+
+// ParseWithProjectionContext works like ParseContext but also returns the projection that the sort requires,
+// that is the `$meta` projection of text search score keys, empty if none.
+func (p *Parser) ParseWithProjectionContext(ctx context.Context, query string) (bson.D, bson.D, error) {
+	sort, err := p.ParseContext(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sort, MetaProjection(sort), nil
+}
 
+// parse parses a given query into the sort and its computed keys.
+func (p *Parser) parse(ctx context.Context, query string) (bson.D, []computedKey, error) {
 	if query == "" {
-		return bson.D{}, nil
+		return bson.D{}, nil, nil
 	}
 
 	for dec, enc := range specialEncode {
 		query = strings.ReplaceAll(query, enc, dec)
 	}
 
-	p.tokenizer = tokenizer.NewTokenizer(
-		query,
-		SkipType, FieldNameType,
-		[]*tokenizer.Spec{
-			tokenizer.NewSpec(`^\s+`, SkipType),
-			tokenizer.NewSpec(`^,`, AndType),
-			tokenizer.NewSpec(`^(=)`, SetType),
-			tokenizer.NewSpec(`^(asc|desc|1|-1)`, SortConditionType),
-			tokenizer.NewSpec(`^[^=]*`, FieldNameType),
-		},
-		p.policy,
-	)
+	scanFunc := scan
+	if p.syntax == PrefixSyntax {
+		scanFunc = scanPrefix
+	}
+
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
 
-	p.lookahead, err = p.tokenizer.GetNextToken()
+	state := &parseState{
+		Parser:       p,
+		tokenizer:    tokenizer.NewScannerTokenizer(query, SkipType, FieldNameType, scanFunc, p.policy).WithContext(ctx),
+		keys:         map[string]bool{},
+		capabilities: CapabilitiesFrom(ctx),
+	}
+
+	err := state.next()
 	if err != nil {
-		return nil, err //nolint:wrapcheck
+		return nil, nil, err
 	}
 
-	return p.expression()
+	sort, err := state.expression()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sort, state.computed, nil
 }
 
 /*
  * <expression>
  *   | <sort_statement>
  *   | <sort_statement> "," <sort_statement>
+ *   | <prefix_statement>
+ *   | <prefix_statement> "," <prefix_statement>
  * .
  */
-func (p *Parser) expression() ([]bson.E, error) {
+func (p *parseState) expression() ([]bson.E, error) {
 	sortStatements := []bson.E{}
 
 	if p.lookahead == nil {
-		return nil, errs.NewErrUnexpectedInputEnd(FieldNameType.String())
+		if p.syntax == PrefixSyntax {
+			return nil, p.tokenizer.Unexpected(p.lookahead, DirectionType, FieldNameType)
+		}
+
+		return nil, p.tokenizer.Unexpected(p.lookahead, FieldNameType)
+	}
+
+	statement := p.sortStatement
+	if p.syntax == PrefixSyntax {
+		statement = p.prefixStatement
 	}
 
-	sortStatement, err := p.sortStatement()
+	sortStatement, err := statement()
 	if err != nil {
 		return nil, err
 	}
@@ -135,10 +230,12 @@ func (p *Parser) expression() ([]bson.E, error) {
 
 /*
  * <sort_statement>
- *   : <key> "=" <sort_condition>
+ *   : <key> "=" <sort_condition> <modifiers>
+ *   | <key> "=" "size:" <sort_condition> <modifiers>
+ *   | <key> "=" "meta"
  * .
  */
-func (p *Parser) sortStatement() (*bson.E, error) {
+func (p *parseState) sortStatement() (*bson.E, error) {
 	keyToken, err := p.eat(FieldNameType)
 	if err != nil {
 		return nil, err
@@ -149,11 +246,36 @@ func (p *Parser) sortStatement() (*bson.E, error) {
 		return nil, err
 	}
 
+	computed := computedKey{}
+
+	if p.lookahead != nil && p.lookahead.Type == FunctionType {
+		computed.function = strings.TrimSuffix(p.lookahead.Value, ":")
+
+		err = p.next()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	sortConditionToken, err := p.eat(SortConditionType)
 	if err != nil {
 		return nil, err
 	}
 
+	if sortConditionToken.Value == metaSort {
+		if computed.function != "" {
+			return nil, errs.NewErrUnexpectedTokenWithDiagnostic(errs.NewDiagnostic(
+				p.tokenizer.GetQuery(), sortConditionToken.Start, len(sortConditionToken.Value), sortConditionToken.Value))
+		}
+
+		return p.meta(keyToken.Value)
+	}
+
+	err = p.modifiers(&computed)
+	if err != nil {
+		return nil, err
+	}
+
 	var sort int
 	if sortConditionToken.Value == "asc" || sortConditionToken.Value == "1" {
 		sort = 1
@@ -161,5 +283,119 @@ func (p *Parser) sortStatement() (*bson.E, error) {
 		sort = -1
 	}
 
-	return &bson.E{Key: keyToken.Value, Value: sort}, nil
+	element, err := p.key(keyToken.Value, sort)
+	if err != nil {
+		return nil, err
+	}
+
+	if computed != (computedKey{}) {
+		computed.index = p.count - 1
+		p.computed = append(p.computed, computed)
+	}
+
+	return element, nil
+}
+
+// meta returns the sort key of the text search score, if the filter has a text search.
+// The score is sorted descending, so smart parsers must allow this direction.
+func (p *parseState) meta(field string) (*bson.E, error) {
+	element, err := p.key(field, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.capabilities&TextSearch == 0 {
+		return nil, TextSearchRequiredError{field: field}
+	}
+
+	element.Value = textScore()
+
+	return element, nil
+}
+
+/*
+ * <modifiers>
+ *   : ""
+ *   | ":nullslast" <modifiers>
+ *   | ":nullsfirst" <modifiers>
+ *   | ":ci" <modifiers>
+ * .
+ */
+func (p *parseState) modifiers(computed *computedKey) error {
+	for p.lookahead != nil && p.lookahead.Type == ModifierType {
+		modifier := strings.TrimPrefix(p.lookahead.Value, ":")
+
+		switch {
+		case modifier == caseInsensitive && !computed.caseInsensitive && computed.function == "":
+			computed.caseInsensitive = true
+		case modifier != caseInsensitive && computed.nulls == "":
+			computed.nulls = modifier
+		default:
+			return errs.NewErrUnexpectedTokenWithDiagnostic(errs.NewDiagnostic(
+				p.tokenizer.GetQuery(), p.lookahead.Start, len(p.lookahead.Value), p.lookahead.Value))
+		}
+
+		err := p.next()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/*
+ * <prefix_statement>
+ *   : <key>
+ *   | "+" <key>
+ *   | "-" <key>
+ * .
+ */
+func (p *parseState) prefixStatement() (*bson.E, error) {
+	sort := 1
+
+	if p.lookahead.Type == DirectionType {
+		if p.lookahead.Value == "-" {
+			sort = -1
+		}
+
+		err := p.next()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	keyToken, err := p.eat(FieldNameType)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.key(keyToken.Value, sort)
+}
+
+// key returns the sort key if allowed by the reference of smart parsers and the key limit.
+func (p *parseState) key(field string, sort int) (*bson.E, error) {
+	if p.sortable != nil {
+		allowed, known := p.sortable[field]
+		if !known {
+			return nil, UnsortableFieldError{field: field}
+		}
+
+		if allowed&directionOf(sort) == 0 {
+			return nil, DirectionNotAllowedError{field: field, sort: sort}
+		}
+
+		if p.keys[field] {
+			return nil, DuplicateKeyError{field: field}
+		}
+	}
+
+	p.keys[field] = true
+	p.count++
+
+	if p.keyLimit > 0 && p.count > p.keyLimit {
+		return nil, KeyLimitError{limit: p.keyLimit}
+	}
+
+	return &bson.E{Key: field, Value: sort}, nil
 }
@@ -4,13 +4,16 @@ package sort
 import (
 	"context"
 	"runtime"
+	"sync"
 	"testing"
 
 	"github.com/StevenCyb/go-mongo-tools/errs"
 	testutil "github.com/StevenCyb/go-mongo-tools/mongo/test_util"
 	"github.com/StevenCyb/go-mongo-tools/tokenizer"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 func TestParsing(t *testing.T) {
@@ -73,22 +76,51 @@ func TestParsing(t *testing.T) {
 			)
 		})
 
+		t.Run("WithKeywordPrefixedFieldNames_Success", func(t *testing.T) {
+			t.Parallel()
+
+			testutil.ExecuteSuccessTest(t,
+				NewParser(nil),
+				"metadata=asc,meta_x=desc,ascending=1,description.a=-1",
+				bson.D{
+					bson.E{Key: "metadata", Value: 1},
+					bson.E{Key: "meta_x", Value: -1},
+					bson.E{Key: "ascending", Value: 1},
+					bson.E{Key: "description.a", Value: -1},
+				},
+			)
+		})
+
 		t.Run("WithUnknownLiteral_Fail", func(t *testing.T) {
 			t.Parallel()
 
 			testutil.ExecuteFailedTest(t,
 				NewParser(nil),
 				"firstName=?",
-				errs.NewErrUnexpectedTokenType(11, "FIELD_NAME", "SORT_CRITERIA"),
+				errs.NewErrUnexpectedTokenTypeWithDiagnostic(errs.NewDiagnostic(
+					"firstName=?", 10, 1, "FIELD_NAME", "SORT_CRITERIA")),
 			)
 		})
+
+		t.Run("WithIncompleteExpression_Fail", func(t *testing.T) {
+			t.Parallel()
+
+			testutil.ExecuteFailedTest(t,
+				NewParser(nil),
+				"firstName=",
+				errs.NewErrUnexpectedInputEndWithDiagnostic(errs.NewDiagnostic(
+					"firstName=", 10, 0, "", "SORT_CRITERIA")),
+			)
+		})
+
 		t.Run("WithUnexpectedSeparator_Fail", func(t *testing.T) {
 			t.Parallel()
 
 			testutil.ExecuteFailedTest(t,
 				NewParser(nil),
 				"firstName=asc+lastName=asc",
-				errs.NewErrUnexpectedTokenType(22, "FIELD_NAME", ","),
+				errs.NewErrUnexpectedTokenTypeWithDiagnostic(errs.NewDiagnostic(
+					"firstName=asc+lastName=asc", 13, 9, "FIELD_NAME", ",")),
 			)
 		})
 	})
@@ -114,9 +146,251 @@ func TestParsing(t *testing.T) {
 				NewParser(
 					tokenizer.NewPolicy(tokenizer.WhitelistPolicy, "a", "b")),
 				"a=asc,b=desc,c=desc",
-				errs.NewErrPolicyViolation("c"),
+				errs.NewErrPolicyViolationWithDiagnostic(errs.NewDiagnostic("a=asc,b=desc,c=desc", 13, 1, "c")),
+			)
+		})
+	})
+
+	t.Run("WithPrefixSyntax", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("WithEmptyQuery_Success", func(t *testing.T) {
+			t.Parallel()
+
+			testutil.ExecuteSuccessTest(t,
+				NewParser(nil, PrefixSyntax),
+				"",
+				bson.D{},
+			)
+		})
+
+		t.Run("WithMultipleSortingCriteria_Success", func(t *testing.T) {
+			t.Parallel()
+
+			testutil.ExecuteSuccessTest(t,
+				NewParser(nil, PrefixSyntax),
+				"-createdAt,+name,title, -last-name , address.city",
+				bson.D{
+					bson.E{Key: "createdAt", Value: -1},
+					bson.E{Key: "name", Value: 1},
+					bson.E{Key: "title", Value: 1},
+					bson.E{Key: "last-name", Value: -1},
+					bson.E{Key: "address.city", Value: 1},
+				},
+			)
+		})
+
+		t.Run("WithDecodedPlus_Success", func(t *testing.T) {
+			t.Parallel()
+
+			testutil.ExecuteSuccessTest(t,
+				NewParser(nil, PrefixSyntax),
+				" name,-age",
+				bson.D{bson.E{Key: "name", Value: 1}, bson.E{Key: "age", Value: -1}},
+			)
+		})
+
+		t.Run("WithMissingField_Fail", func(t *testing.T) {
+			t.Parallel()
+
+			testutil.ExecuteFailedTest(t,
+				NewParser(nil, PrefixSyntax),
+				"-name,-",
+				errs.NewErrUnexpectedInputEndWithDiagnostic(errs.NewDiagnostic(
+					"-name,-", 7, 0, "", "FIELD_NAME")),
+			)
+			testutil.ExecuteFailedTest(t,
+				NewParser(nil, PrefixSyntax),
+				"name,",
+				errs.NewErrUnexpectedInputEndWithDiagnostic(errs.NewDiagnostic(
+					"name,", 5, 0, "", "DIRECTION", "FIELD_NAME")),
+			)
+			testutil.ExecuteFailedTest(t,
+				NewParser(nil, PrefixSyntax),
+				"--name",
+				errs.NewErrUnexpectedTokenTypeWithDiagnostic(errs.NewDiagnostic(
+					"--name", 1, 1, "DIRECTION", "FIELD_NAME")),
+			)
+		})
+
+		t.Run("WithMissingSeparator_Fail", func(t *testing.T) {
+			t.Parallel()
+
+			testutil.ExecuteFailedTest(t,
+				NewParser(nil, PrefixSyntax),
+				"-name age",
+				errs.NewErrUnexpectedTokenTypeWithDiagnostic(errs.NewDiagnostic(
+					"-name age", 6, 3, "FIELD_NAME", ",")),
+			)
+		})
+
+		t.Run("WithDisallowedFieldName_Fail", func(t *testing.T) {
+			t.Parallel()
+
+			testutil.ExecuteFailedTest(t,
+				NewParser(tokenizer.NewPolicy(tokenizer.WhitelistPolicy, "a", "b"), PrefixSyntax),
+				"-a,+b,c",
+				errs.NewErrPolicyViolationWithDiagnostic(errs.NewDiagnostic("-a,+b,c", 6, 1, "c")),
+			)
+		})
+	})
+	t.Run("WithExtendedSyntax", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("WithoutExtensions_Success", func(t *testing.T) {
+			t.Parallel()
+
+			pipeline, err := NewParser(nil).ParsePipeline("name=asc,age=desc")
+			require.NoError(t, err)
+			require.Equal(t, mongo.Pipeline{
+				{{Key: "$sort", Value: bson.D{{Key: "name", Value: 1}, {Key: "age", Value: -1}}}},
+			}, pipeline)
+		})
+
+		t.Run("WithNullsLast_Success", func(t *testing.T) {
+			t.Parallel()
+
+			pipeline, err := NewParser(nil).ParsePipeline("name=asc:nullslast,_id=1")
+			require.NoError(t, err)
+			require.Equal(t, mongo.Pipeline{
+				{{Key: "$addFields", Value: bson.D{{Key: "__sort_0_nulls", Value: isNull("name")}}}},
+				{{Key: "$sort", Value: bson.D{
+					{Key: "__sort_0_nulls", Value: 1},
+					{Key: "name", Value: 1},
+					{Key: "_id", Value: 1},
+				}}},
+				{{Key: "$project", Value: bson.D{{Key: "__sort_0_nulls", Value: 0}}}},
+			}, pipeline)
+		})
+
+		t.Run("WithComputedKeys_Success", func(t *testing.T) {
+			t.Parallel()
+
+			pipeline, err := NewParser(nil).ParsePipeline("age=1,tags=size:desc,title=asc:ci:nullsfirst")
+			require.NoError(t, err)
+			require.Equal(t, mongo.Pipeline{
+				{{Key: "$addFields", Value: bson.D{
+					{Key: "__sort_1", Value: size("tags")},
+					{Key: "__sort_2_nulls", Value: isNull("title")},
+					{Key: "__sort_2", Value: toLower("title")},
+				}}},
+				{{Key: "$sort", Value: bson.D{
+					{Key: "age", Value: 1},
+					{Key: "__sort_1", Value: -1},
+					{Key: "__sort_2_nulls", Value: -1},
+					{Key: "__sort_2", Value: 1},
+				}}},
+				{{Key: "$project", Value: bson.D{
+					{Key: "__sort_1", Value: 0},
+					{Key: "__sort_2_nulls", Value: 0},
+					{Key: "__sort_2", Value: 0},
+				}}},
+			}, pipeline)
+		})
+
+		t.Run("WithPlainParse_Fail", func(t *testing.T) {
+			t.Parallel()
+
+			testutil.ExecuteFailedTest(t,
+				NewParser(nil),
+				"age=1,title=asc:ci",
+				PipelineRequiredError{field: "title"},
 			)
 		})
+
+		t.Run("WithConflictingModifiers_Fail", func(t *testing.T) {
+			t.Parallel()
+
+			testutil.ExecuteFailedTest(t,
+				NewParser(nil),
+				"name=asc:nullslast:nullsfirst",
+				errs.NewErrUnexpectedTokenWithDiagnostic(errs.NewDiagnostic(
+					"name=asc:nullslast:nullsfirst", 18, 11, ":nullsfirst")),
+			)
+			testutil.ExecuteFailedTest(t,
+				NewParser(nil),
+				"tags=size:asc:ci",
+				errs.NewErrUnexpectedTokenWithDiagnostic(errs.NewDiagnostic(
+					"tags=size:asc:ci", 13, 3, ":ci")),
+			)
+		})
+
+		t.Run("WithMissingSortCondition_Fail", func(t *testing.T) {
+			t.Parallel()
+
+			testutil.ExecuteFailedTest(t,
+				NewParser(nil),
+				"tags=size:",
+				errs.NewErrUnexpectedInputEndWithDiagnostic(errs.NewDiagnostic(
+					"tags=size:", 10, 0, "", "SORT_CRITERIA")),
+			)
+		})
+
+		t.Run("WithUnsortableField_Fail", func(t *testing.T) {
+			t.Parallel()
+
+			parser := NewParser(tokenizer.NewPolicy(tokenizer.WhitelistPolicy, "name"))
+			_, err := parser.ParsePipeline("name=asc:ci,secret=asc:nullslast")
+			require.Equal(t, errs.NewErrPolicyViolationWithDiagnostic(errs.NewDiagnostic(
+				"name=asc:ci,secret=asc:nullslast", 12, 6, "secret")), err)
+		})
+	})
+	t.Run("WithTextScore", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("WithTextSearch_Success", func(t *testing.T) {
+			t.Parallel()
+
+			ctx := WithCapabilities(context.Background(), TextSearch)
+			sort, err := NewParser(nil).ParseContext(ctx, "score=meta,_id=1")
+			require.NoError(t, err)
+			require.Equal(t, bson.D{
+				{Key: "score", Value: bson.D{{Key: "$meta", Value: "textScore"}}},
+				{Key: "_id", Value: 1},
+			}, sort)
+		})
+
+		t.Run("WithProjection_Success", func(t *testing.T) {
+			t.Parallel()
+
+			ctx := WithCapabilities(context.Background(), TextSearch)
+			sort, projection, err := NewParser(nil).ParseWithProjectionContext(ctx, "score=meta,_id=1")
+			require.NoError(t, err)
+			require.Equal(t, bson.D{{Key: "score", Value: bson.D{{Key: "$meta", Value: "textScore"}}}, {Key: "_id", Value: 1}}, sort)
+			require.Equal(t, bson.D{{Key: "score", Value: bson.D{{Key: "$meta", Value: "textScore"}}}}, projection)
+
+			sort, projection, err = NewParser(nil).ParseWithProjection("name=asc")
+			require.NoError(t, err)
+			require.Equal(t, bson.D{{Key: "name", Value: 1}}, sort)
+			require.Empty(t, projection)
+
+			_, _, err = NewParser(nil).ParseWithProjection("score=meta")
+			require.Equal(t, TextSearchRequiredError{field: "score"}, err)
+		})
+
+		t.Run("WithoutTextSearch_Fail", func(t *testing.T) {
+			t.Parallel()
+
+			testutil.ExecuteFailedTest(t,
+				NewParser(nil),
+				"_id=1,score=meta",
+				TextSearchRequiredError{field: "score"},
+			)
+		})
+
+		t.Run("WithFunctionOrModifier_Fail", func(t *testing.T) {
+			t.Parallel()
+
+			ctx := WithCapabilities(context.Background(), TextSearch)
+
+			_, err := NewParser(nil).ParseContext(ctx, "score=size:meta")
+			require.Equal(t, errs.NewErrUnexpectedTokenWithDiagnostic(errs.NewDiagnostic(
+				"score=size:meta", 11, 4, "meta")), err)
+
+			_, err = NewParser(nil).ParseContext(ctx, "score=meta:nullslast")
+			require.Equal(t, errs.NewErrUnexpectedTokenTypeWithDiagnostic(errs.NewDiagnostic(
+				"score=meta:nullslast", 10, 10, "MODIFIER", ",")), err)
+		})
 	})
 }
 
@@ -170,5 +444,90 @@ func TestInterpretation(t *testing.T) {
 		require.NoError(t, err)
 
 		testutil.FindCompare(t, collection, nil, sort, items[2], items[1], items[0], items[3])
+
+		t.Run("WithContextPolicy_Success", func(t *testing.T) {
+			t.Parallel()
+
+			type roleKey struct{}
+
+			parser := NewParser(tokenizer.AnyOf(
+				tokenizer.NewPolicy(tokenizer.WhitelistPolicy, "a"),
+				tokenizer.PolicyFunc(func(ctx context.Context, _ string) bool {
+					return ctx.Value(roleKey{}) == "admin"
+				}),
+			))
+
+			sortExpression, err := parser.ParseContext(
+				context.WithValue(context.Background(), roleKey{}, "admin"), "a=asc,b=desc")
+			require.NoError(t, err)
+			require.Equal(t, bson.D{bson.E{Key: "a", Value: 1}, bson.E{Key: "b", Value: -1}}, sortExpression)
+
+			_, err = parser.Parse("a=asc,b=desc")
+			require.Equal(t,
+				errs.NewErrPolicyViolationWithDiagnostic(errs.NewDiagnostic("a=asc,b=desc", 6, 1, "b")), err)
+		})
+	})
+}
+
+func TestConcurrentParsing(t *testing.T) {
+	t.Parallel()
+
+	var (
+		parser    = NewParser(tokenizer.NewPolicy(tokenizer.BlacklistPolicy, "password"))
+		queries   = []string{"name=asc", "name=asc,age=desc,address.city=asc", "password=asc", "name="}
+		expected  = make([]bson.D, len(queries))
+		expectErr = make([]error, len(queries))
+		wait      sync.WaitGroup
+	)
+
+	for i, query := range queries {
+		expected[i], expectErr[i] = parser.Parse(query)
+	}
+
+	for worker := 0; worker < 16; worker++ {
+		wait.Add(1)
+
+		go func(worker int) {
+			defer wait.Done()
+
+			for i := 0; i < 50; i++ {
+				index := (worker + i) % len(queries)
+				sort, err := parser.ParseContext(context.Background(), queries[index])
+				assert.Equal(t, expectErr[index], err)
+				assert.Equal(t, expected[index], sort)
+			}
+		}(worker)
+	}
+
+	wait.Wait()
+}
+
+func BenchmarkParse(b *testing.B) {
+	parser := NewParser(tokenizer.NewPolicy(tokenizer.BlacklistPolicy, "password"))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := parser.Parse("name=asc,age=desc,address.city=asc")
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseParallel(b *testing.B) {
+	parser := NewParser(tokenizer.NewPolicy(tokenizer.BlacklistPolicy, "password"))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, err := parser.Parse("name=asc,age=desc,address.city=asc")
+			if err != nil {
+				b.Error(err)
+			}
+		}
 	})
 }
@@ -0,0 +1,129 @@
+package sort
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ComputedKeyPrefix is the prefix of the fields that are added to sort by computed keys.
+const ComputedKeyPrefix = "__sort_"
+
+// Modifiers and functions of the extended syntax.
+const (
+	nullsLast       = "nullslast"
+	nullsFirst      = "nullsfirst"
+	caseInsensitive = "ci"
+	sizeFunction    = "size"
+)
+
+// computedKey holds the function and modifiers of a sort key.
+type computedKey struct {
+	index           int
+	function        string
+	nulls           string
+	caseInsensitive bool
+}
+
+// ParsePipeline parses a given query into an aggregation pipeline fragment.
+// Without functions or modifiers the fragment only contains a `$sort` stage.
+func (p *Parser) ParsePipeline(query string) (mongo.Pipeline, error) {
+	return p.ParsePipelineContext(context.Background(), query)
+}
+
+// ParsePipelineContext parses a given query into an aggregation pipeline fragment
+// and passes the context to the policy.
+// Computed keys are added by `$addFields` before the `$sort` stage and removed by `$project` after it.
+func (p *Parser) ParsePipelineContext(ctx context.Context, query string) (mongo.Pipeline, error) {
+	sort, computed, err := p.parse(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(computed) == 0 {
+		return mongo.Pipeline{{{Key: "$sort", Value: sort}}}, nil
+	}
+
+	var (
+		fields   = bson.D{}
+		pipeline = bson.D{}
+		cleanup  = bson.D{}
+		next     = 0
+	)
+
+	for index, element := range sort {
+		if next >= len(computed) || computed[next].index != index {
+			pipeline = append(pipeline, element)
+
+			continue
+		}
+
+		key := computed[next]
+		next++
+
+		if key.nulls != "" {
+			name := fmt.Sprintf("%s%d_nulls", ComputedKeyPrefix, index)
+			order := 1
+
+			if key.nulls == nullsFirst {
+				order = -1
+			}
+
+			fields = append(fields, bson.E{Key: name, Value: isNull(element.Key)})
+			pipeline = append(pipeline, bson.E{Key: name, Value: order})
+			cleanup = append(cleanup, bson.E{Key: name, Value: 0})
+		}
+
+		if key.function == "" && !key.caseInsensitive {
+			pipeline = append(pipeline, element)
+
+			continue
+		}
+
+		name := fmt.Sprintf("%s%d", ComputedKeyPrefix, index)
+		expression := toLower(element.Key)
+
+		if key.function == sizeFunction {
+			expression = size(element.Key)
+		}
+
+		fields = append(fields, bson.E{Key: name, Value: expression})
+		pipeline = append(pipeline, bson.E{Key: name, Value: element.Value})
+		cleanup = append(cleanup, bson.E{Key: name, Value: 0})
+	}
+
+	return mongo.Pipeline{
+		{{Key: "$addFields", Value: fields}},
+		{{Key: "$sort", Value: pipeline}},
+		{{Key: "$project", Value: cleanup}},
+	}, nil
+}
+
+// isNull returns an expression that is `1` for null or missing values of given field, otherwise `0`.
+func isNull(field string) bson.D {
+	return bson.D{{Key: "$cond", Value: bson.A{
+		bson.D{{Key: "$eq", Value: bson.A{bson.D{{Key: "$ifNull", Value: bson.A{"$" + field, nil}}}, nil}}},
+		1,
+		0,
+	}}}
+}
+
+// toLower returns an expression that lowercases strings of given field and keeps other values.
+func toLower(field string) bson.D {
+	return bson.D{{Key: "$cond", Value: bson.A{
+		bson.D{{Key: "$eq", Value: bson.A{bson.D{{Key: "$type", Value: "$" + field}}, "string"}}},
+		bson.D{{Key: "$toLower", Value: "$" + field}},
+		"$" + field,
+	}}}
+}
+
+// size returns an expression for the length of the array of given field, `0` if it is no array.
+func size(field string) bson.D {
+	return bson.D{{Key: "$cond", Value: bson.A{
+		bson.D{{Key: "$isArray", Value: "$" + field}},
+		bson.D{{Key: "$size", Value: "$" + field}},
+		0,
+	}}}
+}
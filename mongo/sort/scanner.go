@@ -0,0 +1,92 @@
+package sort
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/StevenCyb/go-mongo-tools/tokenizer"
+)
+
+// sortConditions are the sort conditions in the order of the spec.
+//
+//nolint:gochecknoglobals
+var sortConditions = []string{"asc", "desc", "meta", "1", "-1"}
+
+// modifierTokens are the modifiers in the order of the spec.
+//
+//nolint:gochecknoglobals
+var modifierTokens = []string{":nullslast", ":nullsfirst", ":ci"}
+
+// scan returns the token of the `KeyValueSyntax` at the start of given input.
+// A sort condition that continues like `metadata` is a field name.
+func scan(input string) (tokenizer.Type, int) {
+	if length := tokenizer.ScanSpace(input); length > 0 {
+		return SkipType, length
+	}
+
+	length := 0
+
+	switch input[0] {
+	case ',':
+		return AndType, 1
+	case '=':
+		return SetType, 1
+	}
+
+	if strings.HasPrefix(input, "size:") {
+		return FunctionType, len("size:")
+	}
+
+	for _, modifier := range modifierTokens {
+		if strings.HasPrefix(input, modifier) {
+			return ModifierType, len(modifier)
+		}
+	}
+
+	for _, condition := range sortConditions {
+		if strings.HasPrefix(input, condition) && !continued(input, len(condition)) {
+			return SortConditionType, len(condition)
+		}
+	}
+
+	length = strings.IndexByte(input, '=')
+	if length < 0 {
+		length = len(input)
+	}
+
+	return FieldNameType, length
+}
+
+// continued reports whether the keyword of given length continues as field name like `metadata`.
+func continued(input string, length int) bool {
+	if length == len(input) {
+		return false
+	}
+
+	character := input[length]
+
+	return character == '_' || character == '.' || character >= utf8.RuneSelf ||
+		tokenizer.IsLetter(character) || tokenizer.IsDigit(character)
+}
+
+// scanPrefix reads the token at the start of given input for the `PrefixSyntax`.
+func scanPrefix(input string) (tokenizer.Type, int) {
+	if length := tokenizer.ScanSpace(input); length > 0 {
+		return SkipType, length
+	}
+
+	length := 0
+
+	switch input[0] {
+	case ',':
+		return AndType, 1
+	case '-', '+':
+		return DirectionType, 1
+	}
+
+	for length < len(input) && input[length] != ',' && !tokenizer.IsSpace(input[length]) {
+		length++
+	}
+
+	return FieldNameType, length
+}
@@ -0,0 +1,103 @@
+package sort
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/StevenCyb/go-mongo-tools/tokenizer"
+	"github.com/stretchr/testify/require"
+)
+
+// specs are the regular expressions of the sort grammar,
+// the scanner must produce the same tokens.
+//
+//nolint:gochecknoglobals
+var specs = []*tokenizer.Spec{
+	tokenizer.NewSpec(`^\s+`, SkipType),
+	tokenizer.NewSpec(`^,`, AndType),
+	tokenizer.NewSpec(`^(=)`, SetType),
+	tokenizer.NewSpec(`^size:`, FunctionType),
+	tokenizer.NewSpec(`^:(nullslast|nullsfirst|ci)`, ModifierType),
+	tokenizer.NewSpec(`^(asc|desc|meta|1|-1)[\w.\x{80}-\x{10FFFF}][^=]*`, FieldNameType),
+	tokenizer.NewSpec(`^(asc|desc|meta|1|-1)`, SortConditionType),
+	tokenizer.NewSpec(`^[^=]*`, FieldNameType),
+}
+
+//nolint:gochecknoglobals
+var scannerCorpus = []string{
+	``,
+	`name=asc`,
+	`  name = desc , age=1,b=-1 `,
+	`a=ascending,b=1.5,c=-2`,
+	`a=ASC`,
+	"a\t=\nasc\r\f,b=\vdesc",
+	`a==asc`,
+	`a,b=asc`,
+	`ä.ö=desc`,
+	`name=asc:nullslast,tags=size:desc,title=asc:ci:nullsfirst`,
+	`a=asc:NULLSLAST,b=size:,c=:ci,size:x=1`,
+	`score=meta,a=metadata,b=size:meta:ci`,
+	`metadata=asc,meta_x=desc,meta=meta,1x=-1,-10=1,desc.a=asc,asc+b=1,metaä=1`,
+	"a=\xff",
+	`a=asc,` + strings.Repeat(" ", 1000) + `b=desc`,
+}
+
+// requireSameTokens compares the token stream of the scanner with the one of the specs.
+func requireSameTokens(t *testing.T, query string) {
+	t.Helper()
+
+	expected, expectedErr := tokenizer.NewTokenizer(query, SkipType, FieldNameType, specs, nil).Tokenize()
+	actual, actualErr := tokenizer.NewScannerTokenizer(query, SkipType, FieldNameType, scan, nil).Tokenize()
+
+	require.Equal(t, expected, actual, query)
+	require.Equal(t, expectedErr, actualErr, query)
+}
+
+func TestScanner(t *testing.T) {
+	t.Parallel()
+
+	for _, query := range scannerCorpus {
+		requireSameTokens(t, query)
+	}
+}
+
+//nolint:paralleltest // AllocsPerRun must not run in parallel
+func TestScannerWithoutAllocations(t *testing.T) {
+	query := `name=asc, age=-1,address.city=desc`
+	allocations := testing.AllocsPerRun(100, func() {
+		for part := query; part != ""; {
+			_, length := scan(part)
+			part = part[length:]
+		}
+	})
+
+	require.Zero(t, allocations)
+}
+
+func FuzzScanner(f *testing.F) {
+	for _, query := range scannerCorpus {
+		f.Add(query)
+	}
+
+	f.Fuzz(requireSameTokens)
+}
+
+func BenchmarkTokenizer(b *testing.B) {
+	query := strings.Repeat(`name=asc, age=-1,address.city=desc,`, 50) + `id=1`
+
+	b.Run("Regex", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			_, _ = tokenizer.NewTokenizer(query, SkipType, FieldNameType, specs, nil).Tokenize()
+		}
+	})
+
+	b.Run("Scanner", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			_, _ = tokenizer.NewScannerTokenizer(query, SkipType, FieldNameType, scan, nil).Tokenize()
+		}
+	})
+}
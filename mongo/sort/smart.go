@@ -0,0 +1,126 @@
+package sort
+
+import (
+	"reflect"
+	"strings"
+)
+
+// DefaultKeyLimit is the default maximum number of sort keys of smart parsers.
+const DefaultKeyLimit = 32
+
+// direction is a set of allowed sort directions.
+type direction uint8
+
+const (
+	ascending direction = 1 << iota
+	descending
+)
+
+// directionOf returns the direction of given sort value.
+func directionOf(sort int) direction {
+	if sort < 0 {
+		return descending
+	}
+
+	return ascending
+}
+
+// NewSmartParser creates a new parser that only allows to sort by fields of given reference
+// with a `sort` tag. The tag value `true` allows both directions, `asc` or `desc` only one.
+func NewSmartParser(reference reflect.Type, syntax ...Syntax) (*Parser, error) {
+	if reference == nil {
+		return nil, ErrReferenceIsNil
+	}
+
+	sortable := map[string]direction{}
+
+	err := parseReference(reference, "", sortable, map[reflect.Type]bool{})
+	if err != nil {
+		return nil, err
+	}
+
+	parser := NewParser(nil, syntax...)
+	parser.sortable = sortable
+	parser.keyLimit = DefaultKeyLimit
+
+	return parser, nil
+}
+
+// parseReference collects the sortable fields of given type.
+func parseReference(
+	objectType reflect.Type, path string, sortable map[string]direction, visiting map[reflect.Type]bool,
+) error {
+	switch objectType.Kind() { //nolint:exhaustive
+	case reflect.Ptr, reflect.Array, reflect.Slice:
+		return parseReference(objectType.Elem(), path, sortable, visiting)
+	case reflect.Struct:
+	default:
+		return nil
+	}
+
+	// recursive types are only walked once per branch
+	if visiting[objectType] {
+		return nil
+	}
+
+	visiting[objectType] = true
+	defer delete(visiting, objectType)
+
+	for i := 0; i < objectType.NumField(); i++ {
+		var (
+			field       = objectType.Field(i)
+			bsonTag     = field.Tag.Get("bson")
+			name        = strings.Split(bsonTag, ",")[0]
+			fieldPath   = path + name
+			sortTag, ok = field.Tag.Lookup("sort")
+		)
+
+		if bsonTag == "" || bsonTag == "-" {
+			continue
+		}
+
+		if name == "" && strings.Contains(bsonTag, ",inline") {
+			fieldPath = strings.TrimSuffix(path, ".")
+		} else if name == "" {
+			continue
+		}
+
+		if ok && name != "" {
+			allowed, err := parseSortTag(fieldPath, sortTag)
+			if err != nil {
+				return err
+			}
+
+			if allowed != 0 {
+				sortable[fieldPath] = allowed
+			}
+		}
+
+		if fieldPath != "" {
+			fieldPath += "."
+		}
+
+		err := parseReference(field.Type, fieldPath, sortable, visiting)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseSortTag returns the directions allowed by given tag value.
+func parseSortTag(path, value string) (direction, error) {
+	switch value {
+	case "true":
+		return ascending | descending, nil
+	case "asc":
+		return ascending, nil
+	case "desc":
+		return descending, nil
+	case "false":
+		return 0, nil
+	}
+
+	return 0, InvalidSortTagError{path: path, value: value}
+}
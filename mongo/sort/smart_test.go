@@ -0,0 +1,139 @@
+//nolint:funlen
+package sort
+
+import (
+	"reflect"
+	"testing"
+
+	testutil "github.com/StevenCyb/go-mongo-tools/mongo/test_util"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type smartAddress struct {
+	City    string `bson:"city" sort:"true"`
+	Street  string `bson:"street"`
+	ZipCode string `bson:"zip_code" sort:"asc"`
+}
+
+type smartAudit struct {
+	CreatedAt int64 `bson:"created_at" sort:"desc"`
+}
+
+type smartReference struct {
+	smartAudit `bson:",inline"`
+	Name       string         `bson:"name" sort:"true"`
+	Age        int            `bson:"age" sort:"desc"`
+	Password   string         `bson:"password" sort:"false"`
+	Ignored    string         `bson:"-" sort:"true"`
+	Address    *smartAddress  `bson:"address"`
+	Addresses  []smartAddress `bson:"addresses"`
+	Parent     *smartReference
+	Friends    []*smartReference `bson:"friends"`
+}
+
+func TestSmartParser(t *testing.T) {
+	t.Parallel()
+
+	t.Run("WithNilReference_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewSmartParser(nil)
+		require.ErrorIs(t, err, ErrReferenceIsNil)
+	})
+
+	t.Run("WithInvalidTag_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewSmartParser(reflect.TypeOf(struct {
+			Name string `bson:"name" sort:"yes"`
+		}{}))
+		require.Equal(t, InvalidSortTagError{path: "name", value: "yes"}, err)
+	})
+
+	t.Run("WithSortableFields_Success", func(t *testing.T) {
+		t.Parallel()
+
+		parser, err := NewSmartParser(reflect.TypeOf(smartReference{}))
+		require.NoError(t, err)
+
+		// recursive references like `friends` are not walked again
+		require.Equal(t, map[string]direction{
+			"created_at":         descending,
+			"name":               ascending | descending,
+			"age":                descending,
+			"address.city":       ascending | descending,
+			"address.zip_code":   ascending,
+			"addresses.city":     ascending | descending,
+			"addresses.zip_code": ascending,
+		}, parser.sortable)
+
+		testutil.ExecuteSuccessTest(t,
+			parser,
+			"name=asc,age=desc,address.city=-1,created_at=desc",
+			bson.D{
+				bson.E{Key: "name", Value: 1},
+				bson.E{Key: "age", Value: -1},
+				bson.E{Key: "address.city", Value: -1},
+				bson.E{Key: "created_at", Value: -1},
+			},
+		)
+	})
+
+	t.Run("WithPrefixSyntax_Success", func(t *testing.T) {
+		t.Parallel()
+
+		parser, err := NewSmartParser(reflect.TypeOf(smartReference{}), PrefixSyntax)
+		require.NoError(t, err)
+
+		testutil.ExecuteSuccessTest(t,
+			parser,
+			"-age,address.zip_code",
+			bson.D{bson.E{Key: "age", Value: -1}, bson.E{Key: "address.zip_code", Value: 1}},
+		)
+	})
+
+	t.Run("WithUnsortableField_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		parser, err := NewSmartParser(reflect.TypeOf(smartReference{}))
+		require.NoError(t, err)
+
+		testutil.ExecuteFailedTest(t, parser, "password=asc", UnsortableFieldError{field: "password"})
+		testutil.ExecuteFailedTest(t, parser, "address.street=asc", UnsortableFieldError{field: "address.street"})
+		testutil.ExecuteFailedTest(t, parser, "unknown=asc", UnsortableFieldError{field: "unknown"})
+	})
+
+	t.Run("WithDisallowedDirection_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		parser, err := NewSmartParser(reflect.TypeOf(smartReference{}))
+		require.NoError(t, err)
+
+		testutil.ExecuteFailedTest(t, parser, "age=asc", DirectionNotAllowedError{field: "age", sort: 1})
+		testutil.ExecuteFailedTest(t, parser, "address.zip_code=-1",
+			DirectionNotAllowedError{field: "address.zip_code", sort: -1})
+	})
+
+	t.Run("WithDuplicateKey_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		parser, err := NewSmartParser(reflect.TypeOf(smartReference{}))
+		require.NoError(t, err)
+
+		testutil.ExecuteFailedTest(t, parser, "name=asc,age=desc,name=desc", DuplicateKeyError{field: "name"})
+	})
+
+	t.Run("WithKeyLimit_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		parser, err := NewSmartParser(reflect.TypeOf(smartReference{}))
+		require.NoError(t, err)
+
+		parser.SetKeyLimit(2)
+
+		testutil.ExecuteSuccessTest(t, parser, "name=asc,age=desc",
+			bson.D{bson.E{Key: "name", Value: 1}, bson.E{Key: "age", Value: -1}})
+		testutil.ExecuteFailedTest(t, parser, "name=asc,age=desc,created_at=desc", KeyLimitError{limit: 2})
+	})
+}
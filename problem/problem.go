@@ -0,0 +1,63 @@
+package problem
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ContentType is the media type of problem details documents.
+const ContentType = "application/problem+json"
+
+// Details is a problem details document based on RFC 7807.
+type Details struct {
+	// Operation is the index of the invalid operation of a JSON patch.
+	Operation *int `json:"operation,omitempty"`
+	// Position is the byte offset of the erroneous input within a query.
+	Position *int `json:"position,omitempty"`
+	// Type is an URI reference that identifies the problem type.
+	Type string `json:"type"`
+	// Title is a short, human-readable summary of the problem type.
+	Title string `json:"title"`
+	// Detail is a human-readable explanation of this occurrence of the problem.
+	Detail string `json:"detail,omitempty"`
+	// Instance is an URI reference that identifies this occurrence of the problem.
+	Instance string `json:"instance,omitempty"`
+	// Code is a stable, machine-readable identifier of the problem type.
+	Code string `json:"code"`
+	// Field is the offending field or path.
+	Field string `json:"field,omitempty"`
+	// Parameter is the offending query parameter.
+	Parameter string `json:"parameter,omitempty"`
+	// Snippet renders the query with a caret under the erroneous input.
+	Snippet string `json:"snippet,omitempty"`
+	// Expected contains the token types that would have been valid.
+	Expected []string `json:"expected,omitempty"`
+	// Status is the HTTP status code.
+	Status int `json:"status"`
+	// Errors are the problems of all errors if multiple errors occurred.
+	Errors []Details `json:"errors,omitempty"`
+}
+
+// Write writes the problem details as response.
+func (d Details) Write(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(d.Status)
+
+	if err := json.NewEncoder(w).Encode(d); err != nil {
+		return fmt.Errorf("failed to encode problem details: %w", err)
+	}
+
+	return nil
+}
+
+// FromError converts an error of this module into problem details using default settings.
+func FromError(err error) Details {
+	return NewRenderer("").Render(err)
+}
+
+// Write converts an error of this module into problem details
+// using default settings and writes them as response.
+func Write(w http.ResponseWriter, err error) error {
+	return FromError(err).Write(w)
+}
@@ -0,0 +1,32 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/StevenCyb/go-mongo-tools/mongo/rsql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	_, err := rsql.NewParser(nil).Parse(`name=x=1`)
+	require.Error(t, err)
+
+	recorder := httptest.NewRecorder()
+	require.NoError(t, Write(recorder, err))
+	require.Equal(t, ContentType, recorder.Header().Get("Content-Type"))
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	body := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	require.Equal(t, "about:blank", body["type"])
+	require.Equal(t, err.Error(), body["detail"])
+	require.Equal(t, float64(http.StatusBadRequest), body["status"])
+	require.Contains(t, body, "code")
+	require.Contains(t, body, "position")
+	require.NotContains(t, body, "operation")
+}
@@ -0,0 +1,254 @@
+package problem
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+	"github.com/StevenCyb/go-mongo-tools/mongo/jsonpatch"
+)
+
+// Stable machine-readable codes of problem types.
+const (
+	CodeInternal              = "internal"
+	CodeAlwaysFalse           = errs.CodeAlwaysFalse
+	CodeNoOperation           = errs.CodeNoOperation
+	CodeUnknownOperation      = errs.CodeUnknownOperation
+	CodeInvalidCursor         = errs.CodeInvalidCursor
+	CodeNilReference          = errs.CodeNilReference
+	CodeInvalidLiteral        = errs.CodeInvalidLiteral
+	CodeInvalidConfiguration  = errs.CodeInvalidConfiguration
+	CodeUnexpectedToken       = errs.CodeUnexpectedToken
+	CodeUnexpectedTokenType   = errs.CodeUnexpectedTokenType
+	CodeUnexpectedInputEnd    = errs.CodeUnexpectedInputEnd
+	CodeUnexpectedInput       = errs.CodeUnexpectedInput
+	CodePolicyViolation       = errs.CodePolicyViolation
+	CodeUnboundParameter      = errs.CodeUnboundParameter
+	CodeParameterType         = errs.CodeParameterType
+	CodeUnknownMacro          = errs.CodeUnknownMacro
+	CodeMacroCycle            = errs.CodeMacroCycle
+	CodeMacroDepth            = errs.CodeMacroDepth
+	CodeValueRejected         = errs.CodeValueRejected
+	CodeInvalidOperation      = errs.CodeInvalidOperation
+	CodeOperationNotAllowed   = errs.CodeOperationNotAllowed
+	CodeUnknownField          = errs.CodeUnknownField
+	CodeUnknownPath           = errs.CodeUnknownPath
+	CodeTypeMismatch          = errs.CodeTypeMismatch
+	CodeInvalidType           = errs.CodeInvalidType
+	CodeValueTooSmall         = errs.CodeValueTooSmall
+	CodeValueTooLarge         = errs.CodeValueTooLarge
+	CodeExpressionMismatch    = errs.CodeExpressionMismatch
+	CodeUnsortableField       = errs.CodeUnsortableField
+	CodeSortDirection         = errs.CodeSortDirection
+	CodeDuplicateKey          = errs.CodeDuplicateKey
+	CodeKeyLimit              = errs.CodeKeyLimit
+	CodePipelineRequired      = errs.CodePipelineRequired
+	CodeTextSearchRequired    = errs.CodeTextSearchRequired
+	CodeUnknownOption         = errs.CodeUnknownOption
+	CodeInvalidOption         = errs.CodeInvalidOption
+	CodeUnindexedQuery        = errs.CodeUnindexedQuery
+	CodeMixedProjection       = errs.CodeMixedProjection
+	CodePathCollision         = errs.CodePathCollision
+	CodeInvalidSlice          = errs.CodeInvalidSlice
+	CodeConflictingParameters = errs.CodeConflictingParameters
+	CodeInvalidParameter      = errs.CodeInvalidParameter
+	CodeUnknownPatternKind    = errs.CodeUnknownPatternKind
+)
+
+// internalDetail is the detail of problems of unknown errors.
+const internalDetail = "An internal error occurred."
+
+// classification describes the problem type of an error.
+type classification struct {
+	code   string
+	title  string
+	status int
+}
+
+// classifications holds the problem type of each error code.
+var classifications = map[string]classification{ //nolint:gochecknoglobals
+	CodeUnexpectedToken:       {CodeUnexpectedToken, "Unexpected token", http.StatusBadRequest},
+	CodeUnexpectedTokenType:   {CodeUnexpectedTokenType, "Unexpected token type", http.StatusBadRequest},
+	CodeUnexpectedInputEnd:    {CodeUnexpectedInputEnd, "Unexpected end of input", http.StatusBadRequest},
+	CodeUnexpectedInput:       {CodeUnexpectedInput, "Unexpected input", http.StatusBadRequest},
+	CodePolicyViolation:       {CodePolicyViolation, "Policy violation", http.StatusForbidden},
+	CodeUnboundParameter:      {CodeUnboundParameter, "Unbound parameter", http.StatusBadRequest},
+	CodeParameterType:         {CodeParameterType, "Invalid parameter type", http.StatusBadRequest},
+	CodeUnknownMacro:          {CodeUnknownMacro, "Unknown macro", http.StatusBadRequest},
+	CodeMacroCycle:            {CodeMacroCycle, "Macro cycle", http.StatusBadRequest},
+	CodeMacroDepth:            {CodeMacroDepth, "Macro nested too deep", http.StatusBadRequest},
+	CodeValueRejected:         {CodeValueRejected, "Value rejected", http.StatusUnprocessableEntity},
+	CodeInvalidOperation:      {CodeInvalidOperation, "Invalid operation", http.StatusBadRequest},
+	CodeOperationNotAllowed:   {CodeOperationNotAllowed, "Operation not allowed", http.StatusForbidden},
+	CodeUnknownField:          {CodeUnknownField, "Unknown field", http.StatusUnprocessableEntity},
+	CodeUnknownPath:           {CodeUnknownPath, "Unknown path", http.StatusUnprocessableEntity},
+	CodeTypeMismatch:          {CodeTypeMismatch, "Type mismatch", http.StatusUnprocessableEntity},
+	CodeInvalidType:           {CodeInvalidType, "Invalid type", http.StatusUnprocessableEntity},
+	CodeValueTooSmall:         {CodeValueTooSmall, "Value too small", http.StatusUnprocessableEntity},
+	CodeValueTooLarge:         {CodeValueTooLarge, "Value too large", http.StatusUnprocessableEntity},
+	CodeExpressionMismatch:    {CodeExpressionMismatch, "Expression not matched", http.StatusUnprocessableEntity},
+	CodeUnsortableField:       {CodeUnsortableField, "Field not sortable", http.StatusBadRequest},
+	CodeSortDirection:         {CodeSortDirection, "Sort direction not allowed", http.StatusBadRequest},
+	CodeDuplicateKey:          {CodeDuplicateKey, "Duplicate key", http.StatusBadRequest},
+	CodeKeyLimit:              {CodeKeyLimit, "Too many keys", http.StatusBadRequest},
+	CodePipelineRequired:      {CodePipelineRequired, "Pipeline required", http.StatusBadRequest},
+	CodeTextSearchRequired:    {CodeTextSearchRequired, "Text search required", http.StatusBadRequest},
+	CodeUnknownOption:         {CodeUnknownOption, "Unknown option", http.StatusBadRequest},
+	CodeInvalidOption:         {CodeInvalidOption, "Invalid option", http.StatusBadRequest},
+	CodeUnindexedQuery:        {CodeUnindexedQuery, "Query not supported by an index", http.StatusBadRequest},
+	CodeMixedProjection:       {CodeMixedProjection, "Mixed projection", http.StatusBadRequest},
+	CodePathCollision:         {CodePathCollision, "Path collision", http.StatusBadRequest},
+	CodeInvalidSlice:          {CodeInvalidSlice, "Invalid slice", http.StatusBadRequest},
+	CodeConflictingParameters: {CodeConflictingParameters, "Conflicting parameters", http.StatusBadRequest},
+	CodeInvalidParameter:      {CodeInvalidParameter, "Invalid parameter", http.StatusBadRequest},
+	CodeUnknownPatternKind:    {CodeUnknownPatternKind, "Unknown pattern kind", http.StatusInternalServerError},
+	CodeAlwaysFalse:           {CodeAlwaysFalse, "Filter never matches", http.StatusUnprocessableEntity},
+	CodeInvalidCursor:         {CodeInvalidCursor, "Invalid cursor", http.StatusBadRequest},
+	CodeNilReference:          {CodeNilReference, "Nil reference", http.StatusInternalServerError},
+	CodeInvalidLiteral:        {CodeInvalidLiteral, "Invalid literal", http.StatusBadRequest},
+	CodeNoOperation:           {CodeNoOperation, "No operation to perform", http.StatusBadRequest},
+	CodeUnknownOperation:      {CodeUnknownOperation, "Unknown operation", http.StatusBadRequest},
+	CodeInvalidConfiguration:  {CodeInvalidConfiguration, "Invalid configuration", http.StatusInternalServerError},
+}
+
+// parameterError is implemented by errors of invalid request parameters like `binder.ParameterError`.
+type parameterError interface {
+	Parameter() string
+}
+
+// NewRenderer creates a new renderer. The type of problem details
+// is the code appended to given base URI or `about:blank` if empty.
+func NewRenderer(typeBaseURI string) *Renderer {
+	return &Renderer{
+		typeBaseURI: typeBaseURI,
+		statuses:    map[string]int{},
+	}
+}
+
+// Renderer converts errors of this module into problem details.
+type Renderer struct {
+	translator  errs.Translator
+	statuses    map[string]int
+	typeBaseURI string
+}
+
+// SetStatus overrides the default HTTP status for given code.
+func (r *Renderer) SetStatus(code string, status int) {
+	r.statuses[code] = status
+}
+
+// SetTranslator sets the translator used to localize the detail of problems.
+func (r *Renderer) SetTranslator(translator errs.Translator) {
+	r.translator = translator
+}
+
+// Render converts given error into problem details.
+// Unknown errors result in an internal problem.
+func (r *Renderer) Render(err error) Details {
+	return r.RenderLocalized(err, "")
+}
+
+// RenderLocalized converts given error into problem details
+// with the detail translated into given locale if supported.
+// An `errs.Chain` error is classified by its first error and lists the problem of each error in `Errors`.
+// A nil error results in empty details.
+func (r *Renderer) RenderLocalized(err error, locale string) Details {
+	if err == nil {
+		return Details{}
+	}
+
+	all := errs.Errors(err)
+	if len(all) == 1 {
+		return r.render(all[0], locale)
+	}
+
+	details := r.render(all[0], locale)
+	messages := make([]string, 0, len(all))
+
+	for _, each := range all {
+		entry := r.render(each, locale)
+		details.Errors = append(details.Errors, entry)
+		messages = append(messages, entry.Detail)
+	}
+
+	details.Detail = strings.Join(messages, ": ")
+
+	return details
+}
+
+// render converts a single error into problem details.
+func (r *Renderer) render(err error, locale string) Details {
+	details := Details{Detail: errs.Localize(r.translator, locale, err)}
+	kind := classification{code: CodeInternal, title: "Internal error", status: http.StatusInternalServerError}
+
+	for current := err; current != nil; current = errors.Unwrap(current) {
+		if parameterErr, ok := current.(parameterError); ok && details.Parameter == "" {
+			details.Parameter = parameterErr.Parameter()
+		}
+
+		if operationErr, ok := current.(jsonpatch.OperationError); ok {
+			index := operationErr.Index()
+			details.Operation = &index
+			details.Field = string(operationErr.Operation().Path)
+
+			continue
+		}
+
+		if moduleErr, ok := current.(errs.Error); ok {
+			kind = classifyCode(moduleErr.Code())
+			r.applyContext(&details, moduleErr)
+
+			break
+		}
+	}
+
+	// unknown errors may contain internals that must not be exposed
+	if kind.code == CodeInternal {
+		details.Detail = internalDetail
+	}
+
+	details.Code = kind.code
+	details.Title = kind.title
+	details.Status = kind.status
+	details.Type = "about:blank"
+
+	if status, exists := r.statuses[kind.code]; exists {
+		details.Status = status
+	}
+
+	if r.typeBaseURI != "" {
+		details.Type = r.typeBaseURI + kind.code
+	}
+
+	return details
+}
+
+// applyContext adds the position and field of the error to details.
+func (r *Renderer) applyContext(details *Details, err errs.Error) {
+	var diagnosticErr errs.DiagnosticError
+	if errors.As(err, &diagnosticErr) {
+		diagnostic := diagnosticErr.GetDiagnostic()
+
+		if diagnostic.Query != "" {
+			position := diagnostic.Position
+			details.Position = &position
+			details.Snippet = diagnostic.Snippet()
+			details.Expected = diagnostic.Expected
+		}
+	}
+
+	// policies of JSON patches report their details instead of a path
+	if path := err.Path(); path != "" && (details.Operation == nil || !errors.Is(err, errs.ErrPolicy)) {
+		details.Field = path
+	}
+}
+
+// classifyCode returns the problem type of an error code.
+func classifyCode(code string) classification {
+	if kind, exists := classifications[code]; exists {
+		return kind
+	}
+
+	return classification{code, "Invalid input", http.StatusBadRequest}
+}
@@ -0,0 +1,244 @@
+//nolint:funlen
+package problem
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+	"github.com/StevenCyb/go-mongo-tools/mongo/binder"
+	"github.com/StevenCyb/go-mongo-tools/mongo/collation"
+	"github.com/StevenCyb/go-mongo-tools/mongo/cursor"
+	"github.com/StevenCyb/go-mongo-tools/mongo/jsonpatch"
+	"github.com/StevenCyb/go-mongo-tools/mongo/jsonpatch/operation"
+	"github.com/StevenCyb/go-mongo-tools/mongo/rsql"
+	"github.com/StevenCyb/go-mongo-tools/mongo/sort"
+	"github.com/StevenCyb/go-mongo-tools/tokenizer"
+	"github.com/stretchr/testify/require"
+)
+
+type dummyDoc struct {
+	Name string `bson:"name" sort:"asc"`
+}
+
+func TestRenderer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("UnexpectedToken_Success", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := rsql.NewParser(nil).Parse(`name==`)
+		require.Error(t, err)
+
+		details := FromError(err)
+		require.Equal(t, "about:blank", details.Type)
+		require.Equal(t, CodeUnexpectedInputEnd, details.Code)
+		require.Equal(t, http.StatusBadRequest, details.Status)
+		require.Equal(t, err.Error(), details.Detail)
+		require.NotNil(t, details.Position)
+		require.Equal(t, 6, *details.Position)
+		require.NotEmpty(t, details.Snippet)
+		require.Nil(t, details.Operation)
+	})
+
+	t.Run("PolicyViolation_Success", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := rsql.NewParser(tokenizer.NewPolicy(tokenizer.WhitelistPolicy, "name")).Parse(`age==1`)
+		require.Error(t, err)
+
+		details := FromError(err)
+		require.Equal(t, CodePolicyViolation, details.Code)
+		require.Equal(t, http.StatusForbidden, details.Status)
+		require.Equal(t, "age", details.Field)
+		require.NotNil(t, details.Position)
+		require.Equal(t, 0, *details.Position)
+	})
+
+	t.Run("JSONPatchOperation_Success", func(t *testing.T) {
+		t.Parallel()
+
+		parser, err := jsonpatch.NewSmartParser(reflect.TypeOf(dummyDoc{}))
+		require.NoError(t, err)
+
+		_, err = parser.Parse(
+			operation.Spec{Operation: operation.ReplaceOperation, Path: "name", Value: "a"},
+			operation.Spec{Operation: operation.ReplaceOperation, Path: "unknown", Value: "b"},
+		)
+		require.Error(t, err)
+
+		details := FromError(err)
+		require.Equal(t, CodeUnknownPath, details.Code)
+		require.Equal(t, http.StatusUnprocessableEntity, details.Status)
+		require.Equal(t, "unknown", details.Field)
+		require.NotNil(t, details.Operation)
+		require.Equal(t, 1, *details.Operation)
+		require.Nil(t, details.Position)
+	})
+
+	t.Run("JSONPatchParseAll_Success", func(t *testing.T) {
+		t.Parallel()
+
+		parser, err := jsonpatch.NewSmartParser(reflect.TypeOf(dummyDoc{}))
+		require.NoError(t, err)
+
+		_, err = parser.ParseAll(
+			operation.Spec{Operation: operation.ReplaceOperation, Path: "unknown", Value: "a"},
+			operation.Spec{Operation: operation.ReplaceOperation, Path: "name", Value: "b"},
+			operation.Spec{Operation: operation.RemoveOperation, Path: "other"},
+		)
+		require.Error(t, err)
+
+		details := FromError(err)
+		require.Equal(t, CodeUnknownPath, details.Code)
+		require.Equal(t, http.StatusUnprocessableEntity, details.Status)
+		require.Len(t, details.Errors, 2)
+
+		for i, expected := range []struct {
+			operation int
+			field     string
+		}{{0, "unknown"}, {2, "other"}} {
+			require.Equal(t, CodeUnknownPath, details.Errors[i].Code)
+			require.NotNil(t, details.Errors[i].Operation)
+			require.Equal(t, expected.operation, *details.Errors[i].Operation)
+			require.Equal(t, expected.field, details.Errors[i].Field)
+			require.Equal(t, errs.Errors(err)[i].Error(), details.Errors[i].Detail)
+		}
+	})
+
+	t.Run("SortDirection_Success", func(t *testing.T) {
+		t.Parallel()
+
+		parser, err := sort.NewSmartParser(reflect.TypeOf(dummyDoc{}))
+		require.NoError(t, err)
+
+		_, err = parser.Parse("name=desc")
+		require.Error(t, err)
+
+		details := FromError(err)
+		require.Equal(t, CodeSortDirection, details.Code)
+		require.Equal(t, http.StatusBadRequest, details.Status)
+		require.Equal(t, "name", details.Field)
+	})
+
+	t.Run("Binder_Success", func(t *testing.T) {
+		t.Parallel()
+
+		parametersBinder, err := binder.NewBinder(binder.Config{
+			FilterPolicy: tokenizer.NewPolicy(tokenizer.WhitelistPolicy, "a"),
+		})
+		require.NoError(t, err)
+
+		_, err = parametersBinder.Bind(url.Values{"query": {"b==1"}})
+		require.Error(t, err)
+
+		details := FromError(err)
+		require.Equal(t, CodePolicyViolation, details.Code)
+		require.Equal(t, http.StatusForbidden, details.Status)
+		require.Equal(t, "query", details.Parameter)
+		require.Equal(t, "b", details.Field)
+		require.Empty(t, details.Errors)
+
+		_, err = parametersBinder.Bind(url.Values{"query": {"b==1"}, "limit": {"abc"}})
+		require.Error(t, err)
+
+		details = FromError(err)
+		require.Equal(t, CodePolicyViolation, details.Code)
+		require.Equal(t, http.StatusForbidden, details.Status)
+		require.Equal(t, err.Error(), details.Detail)
+		require.Len(t, details.Errors, 2)
+		require.Equal(t, "query", details.Errors[0].Parameter)
+		require.Equal(t, CodePolicyViolation, details.Errors[0].Code)
+		require.Equal(t, "limit", details.Errors[1].Parameter)
+		require.Equal(t, http.StatusBadRequest, details.Errors[1].Status)
+	})
+
+	t.Run("Sentinel_Success", func(t *testing.T) {
+		t.Parallel()
+
+		details := FromError(jsonpatch.ErrNoOperationToPerform)
+		require.Equal(t, CodeNoOperation, details.Code)
+		require.Equal(t, http.StatusBadRequest, details.Status)
+
+		details = FromError(fmt.Errorf("page: %w", cursor.ErrTamperedToken))
+		require.Equal(t, CodeInvalidCursor, details.Code)
+		require.Equal(t, http.StatusBadRequest, details.Status)
+
+		details = FromError(collation.ErrMissingLocale)
+		require.Equal(t, CodeInvalidOption, details.Code)
+		require.Equal(t, http.StatusBadRequest, details.Status)
+	})
+
+	t.Run("Unknown_Success", func(t *testing.T) {
+		t.Parallel()
+
+		details := FromError(errors.New("connection to 10.0.0.1 refused"))
+		require.Equal(t, CodeInternal, details.Code)
+		require.Equal(t, http.StatusInternalServerError, details.Status)
+		require.Equal(t, internalDetail, details.Detail)
+
+		errChain := errs.Chain{}
+		errChain.AddIf(errs.NewErrPolicyViolation("a"))
+		errChain.AddIf(errors.New("connection to 10.0.0.1 refused"))
+
+		details = FromError(errChain.GetError())
+		require.Equal(t, CodePolicyViolation, details.Code)
+		require.Equal(t, internalDetail, details.Errors[1].Detail)
+		require.NotContains(t, details.Detail, "10.0.0.1")
+	})
+
+	t.Run("InvalidLiteral_Success", func(t *testing.T) {
+		t.Parallel()
+
+		for _, query := range []string{`_id==$oid(123)`, `a==99999999999999999999`} {
+			_, err := rsql.NewParser(nil).Parse(query)
+			require.Error(t, err)
+
+			details := FromError(err)
+			require.Equal(t, CodeInvalidLiteral, details.Code)
+			require.Equal(t, http.StatusBadRequest, details.Status)
+			require.NotNil(t, details.Position)
+			require.NotEmpty(t, details.Snippet)
+		}
+	})
+
+	t.Run("Nil_Success", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, Details{}, FromError(nil))
+		require.Equal(t, Details{}, NewRenderer("").Render(nil))
+		require.Equal(t, Details{}, NewRenderer("").RenderLocalized(nil, "de"))
+	})
+
+	t.Run("TypeBaseURIAndStatus_Success", func(t *testing.T) {
+		t.Parallel()
+
+		renderer := NewRenderer("https://example.com/problems/")
+		renderer.SetStatus(CodeAlwaysFalse, http.StatusOK)
+
+		details := renderer.Render(rsql.ErrAlwaysFalse)
+		require.Equal(t, "https://example.com/problems/"+CodeAlwaysFalse, details.Type)
+		require.Equal(t, http.StatusOK, details.Status)
+	})
+}
+
+func TestRendererLocalized(t *testing.T) {
+	t.Parallel()
+
+	catalog := errs.NewCatalog()
+	catalog.Register("de", map[string]string{errs.CodePolicyViolation: "Feld \"{actual}\" ist nicht erlaubt"})
+
+	renderer := NewRenderer("")
+	renderer.SetTranslator(catalog)
+
+	_, err := rsql.NewParser(tokenizer.NewPolicy(tokenizer.WhitelistPolicy, "name")).Parse(`age==1`)
+	require.Error(t, err)
+
+	require.Equal(t, "Feld \"age\" ist nicht erlaubt", renderer.RenderLocalized(err, "de-DE").Detail)
+	require.Equal(t, err.Error(), renderer.RenderLocalized(err, "en").Detail)
+	require.Equal(t, err.Error(), renderer.Render(err).Detail)
+}
@@ -0,0 +1,41 @@
+package tokenizer
+
+import (
+	"fmt"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+)
+
+// UnknownPatternKindError indicate that a pattern has an unknown kind.
+type UnknownPatternKindError struct {
+	kind PatternKind
+}
+
+func (u UnknownPatternKindError) Error() string {
+	return fmt.Sprintf("unknown pattern kind '%d'", u.kind)
+}
+
+// Kind returns the unknown kind.
+func (u UnknownPatternKindError) Kind() PatternKind {
+	return u.kind
+}
+
+// Code returns the error code.
+func (u UnknownPatternKindError) Code() string {
+	return errs.CodeUnknownPatternKind
+}
+
+// Path returns an empty path since a pattern is no field.
+func (u UnknownPatternKindError) Path() string {
+	return ""
+}
+
+// Details returns the parameters of the error.
+func (u UnknownPatternKindError) Details() map[string]interface{} {
+	return map[string]interface{}{"kind": int(u.kind)}
+}
+
+// Is reports whether the error belongs to given category.
+func (u UnknownPatternKindError) Is(target error) bool {
+	return target == errs.ErrSyntax
+}
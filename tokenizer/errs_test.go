@@ -0,0 +1,24 @@
+package tokenizer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/StevenCyb/go-mongo-tools/errs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnknownPatternKindError(t *testing.T) {
+	t.Parallel()
+
+	err := UnknownPatternKindError{kind: 9}
+	require.Equal(t, "unknown pattern kind '9'", err.Error())
+	require.Equal(t, PatternKind(9), err.Kind())
+	require.Equal(t, errs.CodeUnknownPatternKind, err.Code())
+	require.Empty(t, err.Path())
+	require.Equal(t, map[string]interface{}{"kind": 9}, err.Details())
+	require.True(t, errors.Is(err, errs.ErrSyntax))
+
+	var moduleErr errs.Error
+	require.True(t, errors.As(error(err), &moduleErr))
+}
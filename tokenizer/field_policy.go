@@ -0,0 +1,66 @@
+package tokenizer
+
+import "context"
+
+// FieldPolicy decides if a value like a field name is allowed
+// within a context e.g. depending on role or tenant of the caller.
+type FieldPolicy interface {
+	AllowContext(ctx context.Context, value string) bool
+}
+
+// PolicyFunc is a function that implements a field policy.
+type PolicyFunc func(ctx context.Context, value string) bool
+
+// AllowContext calls the function.
+func (f PolicyFunc) AllowContext(ctx context.Context, value string) bool {
+	return f(ctx, value)
+}
+
+// AllowContext check if a value is allowed, the context is ignored.
+// A nil policy allows everything.
+func (p *Policy) AllowContext(_ context.Context, value string) bool {
+	return p == nil || p.Allow(value)
+}
+
+// AllOf creates a policy that allows a value if all given policies allow it.
+func AllOf(policies ...FieldPolicy) FieldPolicy { //nolint:ireturn
+	return PolicyFunc(func(ctx context.Context, value string) bool {
+		for _, policy := range policies {
+			if !policy.AllowContext(ctx, value) {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+// AnyOf creates a policy that allows a value if any given policy allows it.
+func AnyOf(policies ...FieldPolicy) FieldPolicy { //nolint:ireturn
+	return PolicyFunc(func(ctx context.Context, value string) bool {
+		for _, policy := range policies {
+			if policy.AllowContext(ctx, value) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// Not creates a policy that allows a value if given policy disallows it.
+func Not(policy FieldPolicy) FieldPolicy { //nolint:ireturn
+	return PolicyFunc(func(ctx context.Context, value string) bool {
+		return !policy.AllowContext(ctx, value)
+	})
+}
+
+// Dynamic creates a policy that selects the policy to apply by the context,
+// e.g. by the role of the caller. A nil policy allows everything.
+func Dynamic(selector func(ctx context.Context) FieldPolicy) FieldPolicy { //nolint:ireturn
+	return PolicyFunc(func(ctx context.Context, value string) bool {
+		policy := selector(ctx)
+
+		return policy == nil || policy.AllowContext(ctx, value)
+	})
+}
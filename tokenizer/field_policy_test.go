@@ -0,0 +1,84 @@
+package tokenizer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type roleKey struct{}
+
+func TestFieldPolicy(t *testing.T) {
+	t.Parallel()
+
+	var (
+		publicFields = NewPolicy(WhitelistPolicy, "name", "age")
+		secretFields = NewPolicy(WhitelistPolicy, "salary")
+		admin        = context.WithValue(context.Background(), roleKey{}, "admin")
+		user         = context.WithValue(context.Background(), roleKey{}, "user")
+	)
+
+	t.Run("Policy", func(t *testing.T) {
+		t.Parallel()
+
+		require.True(t, publicFields.AllowContext(user, "name"))
+		require.False(t, publicFields.AllowContext(user, "salary"))
+
+		var nilPolicy *Policy
+		require.True(t, nilPolicy.AllowContext(user, "salary"))
+	})
+
+	t.Run("AllOf", func(t *testing.T) {
+		t.Parallel()
+
+		policy := AllOf(publicFields, NewPolicy(BlacklistPolicy, "age"))
+		require.True(t, policy.AllowContext(user, "name"))
+		require.False(t, policy.AllowContext(user, "age"))
+		require.True(t, AllOf().AllowContext(user, "any"))
+	})
+
+	t.Run("AnyOf", func(t *testing.T) {
+		t.Parallel()
+
+		policy := AnyOf(publicFields, secretFields)
+		require.True(t, policy.AllowContext(user, "name"))
+		require.True(t, policy.AllowContext(user, "salary"))
+		require.False(t, policy.AllowContext(user, "password"))
+		require.False(t, AnyOf().AllowContext(user, "any"))
+	})
+
+	t.Run("Not", func(t *testing.T) {
+		t.Parallel()
+
+		policy := Not(secretFields)
+		require.True(t, policy.AllowContext(user, "name"))
+		require.False(t, policy.AllowContext(user, "salary"))
+	})
+
+	t.Run("Dynamic", func(t *testing.T) {
+		t.Parallel()
+
+		policy := Dynamic(func(ctx context.Context) FieldPolicy {
+			if ctx.Value(roleKey{}) == "admin" {
+				return AnyOf(publicFields, secretFields)
+			}
+
+			return publicFields
+		})
+		require.True(t, policy.AllowContext(admin, "salary"))
+		require.False(t, policy.AllowContext(user, "salary"))
+		require.True(t, policy.AllowContext(user, "name"))
+		require.True(t, Dynamic(func(context.Context) FieldPolicy { return nil }).AllowContext(user, "any"))
+	})
+
+	t.Run("PolicyFunc", func(t *testing.T) {
+		t.Parallel()
+
+		policy := PolicyFunc(func(ctx context.Context, value string) bool {
+			return ctx.Value(roleKey{}) == "admin" || value != "salary"
+		})
+		require.True(t, policy.AllowContext(admin, "salary"))
+		require.False(t, policy.AllowContext(user, "salary"))
+	})
+}
@@ -0,0 +1,199 @@
+package tokenizer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// PatternKind specifies how a pattern of a policy matches values.
+type PatternKind byte
+
+const (
+	// ExactPattern matches the value as it is.
+	ExactPattern PatternKind = iota
+	// GlobPattern matches dot separated paths where `*` matches
+	// a single segment and `**` one or more segments, e.g. `address.*`.
+	GlobPattern
+	// PrefixPattern matches all values that start with the pattern.
+	PrefixPattern
+	// RegexPattern matches values that fully match the regular expression.
+	RegexPattern
+)
+
+const (
+	pathSeparator    = "."
+	wildcardSegment  = "*"
+	deepWildcardPath = "**"
+)
+
+// Pattern is an entry of a policy.
+type Pattern struct {
+	Value string
+	Kind  PatternKind
+}
+
+// Exact creates a pattern that matches the value as it is.
+func Exact(value string) Pattern {
+	return Pattern{Kind: ExactPattern, Value: value}
+}
+
+// Glob creates a pattern that matches dot separated paths with wildcards.
+func Glob(value string) Pattern {
+	return Pattern{Kind: GlobPattern, Value: value}
+}
+
+// Prefix creates a pattern that matches values starting with given prefix.
+func Prefix(value string) Pattern {
+	return Pattern{Kind: PrefixPattern, Value: value}
+}
+
+// Regex creates a pattern that matches values fully matching the expression.
+func Regex(value string) Pattern {
+	return Pattern{Kind: RegexPattern, Value: value}
+}
+
+// segmentNode is a node of a trie of dot separated path segments.
+type segmentNode struct {
+	children map[string]*segmentNode
+	wildcard *segmentNode
+	deep     *segmentNode
+	terminal bool
+}
+
+// newSegmentNode creates a new empty node.
+func newSegmentNode() *segmentNode {
+	return &segmentNode{children: map[string]*segmentNode{}}
+}
+
+// insert adds a path to the trie, wildcards are interpreted if glob is set.
+func (n *segmentNode) insert(segments []string, glob bool) {
+	if len(segments) == 0 {
+		n.terminal = true
+
+		return
+	}
+
+	var next *segmentNode
+
+	switch {
+	case glob && segments[0] == wildcardSegment:
+		if n.wildcard == nil {
+			n.wildcard = newSegmentNode()
+		}
+
+		next = n.wildcard
+	case glob && segments[0] == deepWildcardPath:
+		if n.deep == nil {
+			n.deep = newSegmentNode()
+		}
+
+		next = n.deep
+	default:
+		if _, exists := n.children[segments[0]]; !exists {
+			n.children[segments[0]] = newSegmentNode()
+		}
+
+		next = n.children[segments[0]]
+	}
+
+	next.insert(segments[1:], glob)
+}
+
+// match checks if the trie contains a path matching given segments.
+func (n *segmentNode) match(segments []string) bool {
+	if len(segments) == 0 {
+		return n.terminal
+	}
+
+	if child, exists := n.children[segments[0]]; exists && child.match(segments[1:]) {
+		return true
+	}
+
+	if n.wildcard != nil && n.wildcard.match(segments[1:]) {
+		return true
+	}
+
+	if n.deep != nil {
+		for i := 1; i <= len(segments); i++ {
+			if n.deep.match(segments[i:]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// prefixNode is a node of a trie of prefixes.
+type prefixNode struct {
+	children map[byte]*prefixNode
+	terminal bool
+}
+
+// insert adds a prefix to the trie.
+func (n *prefixNode) insert(prefix string) {
+	node := n
+
+	for i := 0; i < len(prefix); i++ {
+		if node.children == nil {
+			node.children = map[byte]*prefixNode{}
+		}
+
+		if _, exists := node.children[prefix[i]]; !exists {
+			node.children[prefix[i]] = &prefixNode{}
+		}
+
+		node = node.children[prefix[i]]
+	}
+
+	node.terminal = true
+}
+
+// match checks if the trie contains a prefix of given value.
+func (n *prefixNode) match(value string) bool {
+	node := n
+
+	for i := 0; ; i++ {
+		if node.terminal {
+			return true
+		}
+
+		if i == len(value) {
+			return false
+		}
+
+		next, exists := node.children[value[i]]
+		if !exists {
+			return false
+		}
+
+		node = next
+	}
+}
+
+// isSegmentGlob checks if all wildcards of a glob are whole segments.
+func isSegmentGlob(glob string) bool {
+	for _, segment := range strings.Split(glob, pathSeparator) {
+		if segment != wildcardSegment && segment != deepWildcardPath && strings.Contains(segment, wildcardSegment) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// globExpression translates a glob with wildcards within segments to a regular expression.
+func globExpression(glob string) string {
+	parts := strings.Split(glob, deepWildcardPath)
+
+	for i, part := range parts {
+		literals := strings.Split(part, wildcardSegment)
+		for j, literal := range literals {
+			literals[j] = regexp.QuoteMeta(literal)
+		}
+
+		parts[i] = strings.Join(literals, `[^.]*`)
+	}
+
+	return strings.Join(parts, `.+`)
+}
@@ -1,5 +1,11 @@
 package tokenizer
 
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
 // PolicyType represent policy type values.
 type PolicyType byte
 
@@ -14,26 +20,93 @@ const (
 
 // Policy handles policy checks
 // based on configuration.
+// A policy is immutable and can be shared between parsers.
 type Policy struct {
-	values     []string
+	exact      map[string]struct{}
+	segments   *segmentNode
+	prefixes   *prefixNode
+	expression *regexp.Regexp
 	policyType PolicyType
 }
 
 // Allow check if a value is allowed.
 func (p *Policy) Allow(value string) bool {
-	for _, policyValue := range p.values {
-		if policyValue == value {
-			return p.policyType == WhitelistPolicy
-		}
+	return p.match(value) == (p.policyType == WhitelistPolicy)
+}
+
+// match checks if any pattern matches the value.
+func (p *Policy) match(value string) bool {
+	if _, exists := p.exact[value]; exists {
+		return true
+	}
+
+	if p.segments != nil && p.segments.match(strings.Split(value, pathSeparator)) {
+		return true
 	}
 
-	return p.policyType == BlacklistPolicy
+	if p.prefixes != nil && p.prefixes.match(value) {
+		return true
+	}
+
+	return p.expression != nil && p.expression.MatchString(value)
 }
 
 // NewPolicy create a new policy instance with given arguments.
 func NewPolicy(policyType PolicyType, values ...string) *Policy {
-	return &Policy{
+	policy := &Policy{
 		policyType: policyType,
-		values:     values,
+		exact:      make(map[string]struct{}, len(values)),
+	}
+
+	for _, value := range values {
+		policy.exact[value] = struct{}{}
 	}
+
+	return policy
+}
+
+// NewPatternPolicy create a new policy instance that matches values by given patterns.
+// Globs and prefixes are compiled into tries and all regular expressions into a single automaton.
+func NewPatternPolicy(policyType PolicyType, patterns ...Pattern) (*Policy, error) {
+	policy := NewPolicy(policyType)
+	expressions := []string{}
+
+	for _, pattern := range patterns {
+		switch pattern.Kind {
+		case ExactPattern:
+			policy.exact[pattern.Value] = struct{}{}
+		case GlobPattern:
+			if !isSegmentGlob(pattern.Value) {
+				expressions = append(expressions, globExpression(pattern.Value))
+
+				continue
+			}
+
+			if policy.segments == nil {
+				policy.segments = newSegmentNode()
+			}
+
+			policy.segments.insert(strings.Split(pattern.Value, pathSeparator), true)
+		case PrefixPattern:
+			if policy.prefixes == nil {
+				policy.prefixes = &prefixNode{}
+			}
+
+			policy.prefixes.insert(pattern.Value)
+		case RegexPattern:
+			if _, err := regexp.Compile(pattern.Value); err != nil {
+				return nil, fmt.Errorf("invalid policy expression '%s': %w", pattern.Value, err)
+			}
+
+			expressions = append(expressions, pattern.Value)
+		default:
+			return nil, UnknownPatternKindError{kind: pattern.Kind}
+		}
+	}
+
+	if len(expressions) > 0 {
+		policy.expression = regexp.MustCompile(`^(?:(?:` + strings.Join(expressions, `)|(?:`) + `))$`)
+	}
+
+	return policy, nil
 }
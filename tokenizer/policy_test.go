@@ -23,3 +23,70 @@ func TestBlacklist(t *testing.T) {
 	require.False(t, policy.Allow("b"))
 	require.True(t, policy.Allow("c"))
 }
+
+func TestPatternPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Whitelist_Success", func(t *testing.T) {
+		t.Parallel()
+
+		policy, err := NewPatternPolicy(WhitelistPolicy,
+			Exact("name"),
+			Glob("address.*"),
+			Glob("meta.**"),
+			Glob("items.*.price"),
+			Glob("tag_*"),
+			Prefix("ext_"),
+			Regex(`tags\.[0-9]+`),
+		)
+		require.NoError(t, err)
+
+		for _, value := range []string{
+			"name", "address.city", "meta.a", "meta.a.b.c", "items.3.price",
+			"tag_a", "ext_", "ext_value", "tags.0", "tags.12",
+		} {
+			require.True(t, policy.Allow(value), value)
+		}
+
+		for _, value := range []string{
+			"age", "address", "address.city.zip", "meta", "items.3.name",
+			"tag_a.b", "ext", "tags.a", "tags.1x", "xname",
+		} {
+			require.False(t, policy.Allow(value), value)
+		}
+	})
+
+	t.Run("Blacklist_Success", func(t *testing.T) {
+		t.Parallel()
+
+		policy, err := NewPatternPolicy(BlacklistPolicy, Glob("secret.**"), Prefix("_"))
+		require.NoError(t, err)
+
+		require.False(t, policy.Allow("secret.key"))
+		require.False(t, policy.Allow("_id"))
+		require.True(t, policy.Allow("secret"))
+		require.True(t, policy.Allow("name"))
+	})
+
+	t.Run("LiteralWildcard_Success", func(t *testing.T) {
+		t.Parallel()
+
+		policy := NewPolicy(WhitelistPolicy, "a.*")
+		require.True(t, policy.Allow("a.*"))
+		require.False(t, policy.Allow("a.b"))
+	})
+
+	t.Run("InvalidRegex_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewPatternPolicy(WhitelistPolicy, Regex(`(`))
+		require.Error(t, err)
+	})
+
+	t.Run("UnknownKind_Fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewPatternPolicy(WhitelistPolicy, Pattern{Kind: 9, Value: "a"})
+		require.Equal(t, UnknownPatternKindError{kind: 9}, err)
+	})
+}
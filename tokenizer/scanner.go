@@ -0,0 +1,50 @@
+package tokenizer
+
+// ScanFunc scans the token at the start of given input
+// and returns its type and length in bytes.
+// A length of zero indicates that no token matches.
+type ScanFunc func(input string) (Type, int)
+
+// SpecScanner returns a scan function that tries the specs in order
+// and returns the first non-empty match.
+func SpecScanner(spec []*Spec) ScanFunc {
+	return func(input string) (Type, int) {
+		for _, s := range spec {
+			if matched := s.expression.FindString(input); matched != "" {
+				return s.tokenType, len(matched)
+			}
+		}
+
+		return "", 0
+	}
+}
+
+// ScanSpace returns the length of the ASCII whitespace at the start of given input.
+func ScanSpace(input string) int {
+	length := 0
+	for length < len(input) && IsSpace(input[length]) {
+		length++
+	}
+
+	return length
+}
+
+// IsSpace reports whether given character is ASCII whitespace like `\s` of regular expressions.
+func IsSpace(character byte) bool {
+	return character == ' ' || character == '\t' || character == '\n' || character == '\f' || character == '\r'
+}
+
+// IsLetter reports whether given character is an ASCII letter.
+func IsLetter(character byte) bool {
+	return (character >= 'a' && character <= 'z') || (character >= 'A' && character <= 'Z')
+}
+
+// IsDigit reports whether given character is an ASCII digit.
+func IsDigit(character byte) bool {
+	return character >= '0' && character <= '9'
+}
+
+// IsHex reports whether given character is an ASCII hexadecimal digit.
+func IsHex(character byte) bool {
+	return IsDigit(character) || (character >= 'a' && character <= 'f') || (character >= 'A' && character <= 'F')
+}
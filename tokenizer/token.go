@@ -12,6 +12,14 @@ func (t Type) String() string {
 type Token struct {
 	Type  Type
 	Value string
+	// Start is the byte offset of the token within the query.
+	Start int
+	// End is the byte offset after the token within the query.
+	End int
+	// Line is the line of the token starting at 1.
+	Line int
+	// Column is the column (in runes) of the token within the line starting at 1.
+	Column int
 }
 
 // NewToken creates a new token with given arguments.
@@ -1,71 +1,227 @@
 package tokenizer
 
 import (
+	"context"
+	"unicode/utf8"
+
 	"github.com/StevenCyb/go-mongo-tools/errs"
 )
 
+// scanned is a token or error that was scanned ahead.
+type scanned struct {
+	token *Token
+	err   error
+}
+
 // tokenizer that lazily pulls a token from a stream.
 type Tokenizer struct {
+	ctx             context.Context //nolint:containedctx
 	query           string
 	skipTokenType   Type
-	policy          *Policy
+	policy          FieldPolicy
 	policyCheckType Type
-	spec            []*Spec
+	scanFunc        ScanFunc
+	buffer          []scanned
 	cursor          int
+	returned        int
+	line            int
+	column          int
 }
 
-// GetCursorPosition return the position of the cursor.
+// GetCursorPosition return the position of the cursor
+// that is the end of the last returned token.
 func (t *Tokenizer) GetCursorPosition() int {
+	if len(t.buffer) > 0 {
+		return t.returned
+	}
+
 	return t.cursor
 }
 
+// Diagnostic describes given token of the query with its type as actual value,
+// or the end of the query if the token is nil.
+func (t *Tokenizer) Diagnostic(token *Token, expected ...Type) errs.Diagnostic {
+	expectedNames := make([]string, 0, len(expected))
+	for _, tokenType := range expected {
+		expectedNames = append(expectedNames, tokenType.String())
+	}
+
+	if token == nil {
+		return errs.NewDiagnostic(t.query, len(t.query), 0, "", expectedNames...)
+	}
+
+	return errs.NewDiagnostic(t.query, token.Start, len(token.Value), token.Type.String(), expectedNames...)
+}
+
+// Unexpected returns an error for a token that does not match the expected types,
+// or for the end of the query if the token is nil.
+func (t *Tokenizer) Unexpected(token *Token, expected ...Type) error {
+	if token == nil {
+		return errs.NewErrUnexpectedInputEndWithDiagnostic(t.Diagnostic(nil, expected...))
+	}
+
+	return errs.NewErrUnexpectedTokenTypeWithDiagnostic(t.Diagnostic(token, expected...))
+}
+
+// CheckPolicy returns a policy violation if the policy does not allow the value of given token.
+// Parsers use it for tokens that only become field names by their position.
+func (t *Tokenizer) CheckPolicy(token *Token) error {
+	if t.policy != nil && !t.policy.AllowContext(t.ctx, token.Value) {
+		return errs.NewErrPolicyViolationWithDiagnostic(errs.NewDiagnostic(
+			t.query, token.Start, len(token.Value), token.Value))
+	}
+
+	return nil
+}
+
+// GetQuery return the query that is tokenized.
+func (t *Tokenizer) GetQuery() string {
+	return t.query
+}
+
 // HasMoreTokens checks aether we still have more tokens.
 func (t *Tokenizer) HasMoreTokens() bool {
-	return t.cursor < len(t.query)
+	return len(t.buffer) > 0 || t.cursor < len(t.query)
 }
 
 // GetNextToken obtains next token.
 func (t *Tokenizer) GetNextToken() (*Token, error) {
-	if !t.HasMoreTokens() {
-		return nil, nil //nolint:nilnil
+	if len(t.buffer) > 0 {
+		next := t.buffer[0]
+		t.buffer = t.buffer[1:]
+
+		if next.token != nil {
+			t.returned = next.token.End
+		}
+
+		return next.token, next.err
 	}
 
-	part := t.query[t.cursor:]
+	token, err := t.scan()
+	if token != nil {
+		t.returned = token.End
+	}
 
-	for _, spec := range t.spec {
-		matched := spec.expression.FindString(part)
-		if matched == "" {
-			continue
+	return token, err
+}
+
+// Peek returns the n-th upcoming token without consuming it,
+// `Peek(0)` returns the token `GetNextToken` would return next.
+// The token is nil if the input ends before.
+func (t *Tokenizer) Peek(n int) (*Token, error) {
+	for len(t.buffer) <= n {
+		if len(t.buffer) > 0 {
+			last := t.buffer[len(t.buffer)-1]
+			if last.token == nil || last.err != nil {
+				return nil, last.err
+			}
 		}
 
-		t.cursor += len(matched)
-		if spec.tokenType == t.skipTokenType {
-			return t.GetNextToken()
+		token, err := t.scan()
+		t.buffer = append(t.buffer, scanned{token: token, err: err})
+	}
+
+	return t.buffer[n].token, t.buffer[n].err
+}
+
+// Tokenize returns all remaining tokens.
+func (t *Tokenizer) Tokenize() ([]*Token, error) {
+	tokens := []*Token{}
+
+	for {
+		token, err := t.GetNextToken()
+		if err != nil {
+			return tokens, err
+		}
+
+		if token == nil {
+			return tokens, nil
+		}
+
+		tokens = append(tokens, token)
+	}
+}
+
+// scan obtains the next token from the query.
+func (t *Tokenizer) scan() (*Token, error) {
+	for t.cursor < len(t.query) {
+		part := t.query[t.cursor:]
+
+		tokenType, length := t.scanFunc(part)
+		if length == 0 {
+			_, size := utf8.DecodeRuneInString(part)
+
+			return nil, errs.NewErrUnexpectedTokenWithDiagnostic(errs.NewDiagnostic(
+				t.query, t.cursor, size, part[:size]))
+		}
+
+		matched := part[:length]
+		token := &Token{
+			Type:   tokenType,
+			Value:  matched,
+			Start:  t.cursor,
+			End:    t.cursor + length,
+			Line:   t.line,
+			Column: t.column,
+		}
+		t.advance(matched)
+
+		if tokenType == t.skipTokenType {
+			continue
 		}
 
-		if spec.tokenType == t.policyCheckType && t.policy != nil && !t.policy.Allow(matched) {
-			return nil, errs.NewErrPolicyViolation(matched)
+		if tokenType == t.policyCheckType {
+			if err := t.CheckPolicy(token); err != nil {
+				return nil, err
+			}
 		}
 
-		return NewToken(
-			spec.tokenType,
-			matched,
-		), nil
+		return token, nil
+	}
+
+	return nil, nil //nolint:nilnil
+}
+
+// advance moves the cursor behind given matched input.
+func (t *Tokenizer) advance(matched string) {
+	t.cursor += len(matched)
+
+	for _, character := range matched {
+		if character == '\n' {
+			t.line++
+			t.column = 1
+		} else {
+			t.column++
+		}
 	}
+}
 
-	return nil, errs.NewErrUnexpectedToken(
-		t.cursor,
-		part[:1])
+// WithContext sets the context that is passed to the policy.
+func (t *Tokenizer) WithContext(ctx context.Context) *Tokenizer {
+	t.ctx = ctx
+
+	return t
 }
 
 // NewTokenizer create a new tokenizer instance
 // with given parameters.
-func NewTokenizer(query string, skipTokenType, policyCheckType Type, spec []*Spec, policy *Policy) *Tokenizer {
+func NewTokenizer(query string, skipTokenType, policyCheckType Type, spec []*Spec, policy FieldPolicy) *Tokenizer {
+	return NewScannerTokenizer(query, skipTokenType, policyCheckType, SpecScanner(spec), policy)
+}
+
+// NewScannerTokenizer create a new tokenizer instance
+// that uses given scan function instead of specs.
+func NewScannerTokenizer(
+	query string, skipTokenType, policyCheckType Type, scanFunc ScanFunc, policy FieldPolicy,
+) *Tokenizer {
 	return &Tokenizer{
+		ctx:             context.Background(),
 		cursor:          0,
+		line:            1,
+		column:          1,
 		query:           query,
 		skipTokenType:   skipTokenType,
-		spec:            spec,
+		scanFunc:        scanFunc,
 		policyCheckType: policyCheckType,
 		policy:          policy,
 	}
@@ -3,6 +3,7 @@ package tokenizer
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/StevenCyb/go-mongo-tools/errs"
@@ -137,7 +138,209 @@ func TestTokenizer(t *testing.T) {
 			require.Equal(t, separator, token.Value)
 
 			_, err = tokenizer.GetNextToken()
-			require.Equal(t, errs.NewErrPolicyViolation(value), err)
+			require.Equal(t, errs.NewErrPolicyViolationWithDiagnostic(
+				errs.NewDiagnostic("hello=world", 6, 5, value)), err)
 		})
 	})
+
+	t.Run("UnexpectedToken", func(t *testing.T) {
+		t.Parallel()
+
+		tokenizer := NewTokenizer(
+			"hello?",
+			NoneType, NoneType,
+			[]*Spec{
+				NewSpec("^[a-z]+", WordType),
+			},
+			nil)
+
+		_, err := tokenizer.GetNextToken()
+		require.NoError(t, err)
+
+		_, err = tokenizer.GetNextToken()
+		require.Equal(t, errs.NewErrUnexpectedTokenWithDiagnostic(
+			errs.NewDiagnostic("hello?", 5, 1, "?")), err)
+	})
+
+	t.Run("TokenPositions", func(t *testing.T) {
+		t.Parallel()
+
+		var SkipType Type = "SKIP"
+		tokenizer := NewTokenizer(
+			"  hello =\n  wörld",
+			SkipType, NoneType,
+			[]*Spec{
+				NewSpec(`^\s+`, SkipType),
+				NewSpec("^=", EqualType),
+				NewSpec(`^\pL+`, WordType),
+			},
+			nil)
+
+		tokens, err := tokenizer.Tokenize()
+		require.NoError(t, err)
+		require.Equal(t, []*Token{
+			{Type: WordType, Value: "hello", Start: 2, End: 7, Line: 1, Column: 3},
+			{Type: EqualType, Value: "=", Start: 8, End: 9, Line: 1, Column: 9},
+			{Type: WordType, Value: "wörld", Start: 12, End: 18, Line: 2, Column: 3},
+		}, tokens)
+	})
+
+	t.Run("Peek", func(t *testing.T) {
+		t.Parallel()
+
+		tokenizer := NewTokenizer(
+			"hello=world?",
+			NoneType, NoneType,
+			[]*Spec{
+				NewSpec("^=", EqualType),
+				NewSpec("^[a-z]+", WordType),
+			},
+			nil)
+
+		token, err := tokenizer.Peek(1)
+		require.NoError(t, err)
+		require.Equal(t, EqualType, token.Type)
+		require.Equal(t, 0, tokenizer.GetCursorPosition())
+
+		token, err = tokenizer.Peek(0)
+		require.NoError(t, err)
+		require.Equal(t, key, token.Value)
+
+		_, err = tokenizer.Peek(3)
+		require.Equal(t, errs.NewErrUnexpectedTokenWithDiagnostic(
+			errs.NewDiagnostic("hello=world?", 11, 1, "?")), err)
+
+		token, err = tokenizer.GetNextToken()
+		require.NoError(t, err)
+		require.Equal(t, key, token.Value)
+		require.Equal(t, 5, tokenizer.GetCursorPosition())
+
+		tokens, err := tokenizer.Tokenize()
+		require.Error(t, err)
+		require.Len(t, tokens, 2)
+	})
+
+	t.Run("PeekAfterGetNextToken", func(t *testing.T) {
+		t.Parallel()
+
+		tokenizer := NewTokenizer(
+			"hello=world",
+			NoneType, NoneType,
+			[]*Spec{
+				NewSpec("^=", EqualType),
+				NewSpec("^[a-z]+", WordType),
+			},
+			nil)
+
+		token, err := tokenizer.GetNextToken()
+		require.NoError(t, err)
+		require.Equal(t, key, token.Value)
+
+		token, err = tokenizer.Peek(1)
+		require.NoError(t, err)
+		require.Equal(t, "world", token.Value)
+		require.Equal(t, 5, tokenizer.GetCursorPosition())
+
+		token, err = tokenizer.GetNextToken()
+		require.NoError(t, err)
+		require.Equal(t, EqualType, token.Type)
+		require.Equal(t, 6, tokenizer.GetCursorPosition())
+	})
+
+	t.Run("PeekEndOfInput", func(t *testing.T) {
+		t.Parallel()
+
+		tokenizer := NewTokenizer("hello", NoneType, NoneType, []*Spec{NewSpec("^[a-z]+", WordType)}, nil)
+
+		token, err := tokenizer.Peek(2)
+		require.NoError(t, err)
+		require.Nil(t, token)
+
+		token, err = tokenizer.GetNextToken()
+		require.NoError(t, err)
+		require.Equal(t, key, token.Value)
+
+		token, err = tokenizer.GetNextToken()
+		require.NoError(t, err)
+		require.Nil(t, token)
+	})
+
+	t.Run("ScanFunc", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			SkipType Type = "SKIP"
+			scan          = func(input string) (Type, int) {
+				switch {
+				case input[0] == ' ':
+					return SkipType, 1
+				case input[0] == '=':
+					return EqualType, 1
+				case input[0] >= 'a' && input[0] <= 'z':
+					return WordType, len(input) - len(strings.TrimLeft(input, "abcdefghijklmnopqrstuvwxyz"))
+				}
+
+				return "", 0
+			}
+			tokenizer = NewScannerTokenizer(key+strings.Repeat(" ", 10000)+separator+value+"?", SkipType, NoneType, scan, nil)
+		)
+
+		tokens, err := tokenizer.Tokenize()
+		require.Equal(t, errs.NewErrUnexpectedTokenWithDiagnostic(
+			errs.NewDiagnostic(tokenizer.GetQuery(), 10011, 1, "?")), err)
+		require.Len(t, tokens, 3)
+		require.Equal(t, []string{key, separator, value}, []string{tokens[0].Value, tokens[1].Value, tokens[2].Value})
+		require.Equal(t, 10006, tokens[2].Start)
+	})
+
+	t.Run("Unexpected", func(t *testing.T) {
+		t.Parallel()
+
+		tokenizer := NewTokenizer("hello", NoneType, NoneType, []*Spec{NewSpec("^[a-z]+", WordType)}, nil)
+
+		token, err := tokenizer.GetNextToken()
+		require.NoError(t, err)
+		require.Equal(t, errs.NewErrUnexpectedTokenTypeWithDiagnostic(
+			errs.NewDiagnostic("hello", 0, 5, "WORD", "EQUAL")), tokenizer.Unexpected(token, EqualType))
+		require.Equal(t, errs.NewErrUnexpectedInputEndWithDiagnostic(
+			errs.NewDiagnostic("hello", 5, 0, "", "EQUAL", "WORD")), tokenizer.Unexpected(nil, EqualType, WordType))
+	})
+
+	t.Run("CheckPolicy", func(t *testing.T) {
+		t.Parallel()
+
+		tokenizer := NewTokenizer("1", NoneType, WordType, nil, NewPolicy(WhitelistPolicy, "2"))
+
+		require.Equal(t, errs.NewErrPolicyViolationWithDiagnostic(errs.NewDiagnostic("1", 0, 1, "1")),
+			tokenizer.CheckPolicy(&Token{Type: WordType, Value: "1"}))
+		require.NoError(t, tokenizer.CheckPolicy(&Token{Type: WordType, Value: "2"}))
+	})
+}
+
+func TestScanSpace(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, 0, ScanSpace(""))
+	require.Equal(t, 0, ScanSpace("a "))
+	require.Equal(t, 5, ScanSpace(" \t\n\f\ra"))
+	require.False(t, IsSpace('\v'))
+}
+
+func TestIsCharacterClass(t *testing.T) {
+	t.Parallel()
+
+	for _, character := range []byte("azAZ") {
+		require.True(t, IsLetter(character))
+	}
+
+	for _, character := range []byte("09aAfF") {
+		require.True(t, IsHex(character))
+	}
+
+	for _, character := range []byte("@[`{_") {
+		require.False(t, IsLetter(character))
+	}
+
+	require.True(t, IsDigit('0') && IsDigit('9'))
+	require.False(t, IsDigit('a') || IsHex('g') || IsHex('G'))
 }